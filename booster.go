@@ -1,16 +1,64 @@
 package lightgbm
 
 import (
+	"errors"
+	"io"
+	"os"
 	"runtime"
+	"sort"
 	"strings"
 	"unsafe"
 )
 
 // -----------------------------------------------------------------------------
 
+// Objective computes the first and second order gradients of the loss for the current raw predictions.
+// For multiclass models, predictions, gradients and hessians are grouped by class, i.e. the score of row
+// i for class k is located at k*len(labels)+i.
+type Objective func(preds []float64, labels []float64) (grad []float64, hess []float64)
+
+// MetricFunc evaluates the predictions of a dataset. The layout of the predictions is the same as the one
+// passed to Objective. Weights is nil if the dataset has no weights.
+type MetricFunc func(preds []float64, labels []float64, weights []float64) float64
+
+// -----------------------------------------------------------------------------
+
+type EvalResult struct {
+	Name           string
+	Value          float64
+	HigherIsBetter bool
+}
+
+type FeatureScore struct {
+	Name  string
+	Score float64
+}
+
+type SaveModelOptions struct {
+	StartIteration int
+
+	// NumIteration is the number of iterations to save. Use NumIterationAll to save every iteration or
+	// NumIterationBest to save up to the best iteration found by Train.
+	NumIteration int
+
+	FeatureImportance FeatureImportance
+}
+
+type CustomMetric struct {
+	Name           string
+	HigherIsBetter bool
+	Eval           MetricFunc
+}
+
 type Booster struct {
 	ptr              unsafe.Pointer
+	trainDataset     *Dataset
 	validDatasetList []*Dataset
+	customMetrics    []CustomMetric
+	evalNames        []string
+	bestIteration    int
+	bestScores       [][]EvalResult
+	categoryEncoder  *CategoryEncoder
 }
 
 // -----------------------------------------------------------------------------
@@ -58,6 +106,7 @@ func NewBoosterFromDataset(ds *Dataset, parameters []string, validators []*Datas
 	// Create the booster object
 	b := &Booster{
 		ptr:              boosterPtr,
+		trainDataset:     ds,
 		validDatasetList: validators,
 	}
 	runtime.SetFinalizer(b, func(b *Booster) {
@@ -74,6 +123,67 @@ func NewBoosterFromString(data string) (*Booster, error) {
 		return nil, err
 	}
 
+	// Load the category encoder, if any. Only the last line can hold it.
+	ofs := strings.LastIndexByte(strings.TrimRight(data, modelTrailingChars), '\n')
+	e, err := loadCategoricalTrailer([]byte(data[ofs+1:]))
+	if err != nil {
+		boosterFree(boosterPtr)
+		return nil, err
+	}
+
+	b := newBoosterFromPtr(boosterPtr)
+	b.categoryEncoder = e
+
+	// Done
+	return b, nil
+}
+
+func NewBoosterFromFile(filename string) (*Booster, error) {
+	boosterPtr, err := boosterCreateFromModelFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the category encoder, if any
+	e, err := loadCategoricalTrailerFromFile(filename)
+	if err != nil {
+		boosterFree(boosterPtr)
+		return nil, err
+	}
+
+	b := newBoosterFromPtr(boosterPtr)
+	b.categoryEncoder = e
+
+	// Done
+	return b, nil
+}
+
+func NewBoosterFromReader(r io.Reader) (*Booster, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	boosterPtr, err := boosterLoadModelFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the category encoder, if any
+	e, err := loadCategoricalTrailer(data)
+	if err != nil {
+		boosterFree(boosterPtr)
+		return nil, err
+	}
+
+	b := newBoosterFromPtr(boosterPtr)
+	b.categoryEncoder = e
+
+	// Done
+	return b, nil
+}
+
+func newBoosterFromPtr(boosterPtr unsafe.Pointer) *Booster {
 	// Create the booster object
 	b := &Booster{
 		ptr: boosterPtr,
@@ -83,25 +193,362 @@ func NewBoosterFromString(data string) (*Booster, error) {
 	})
 
 	// Done
-	return b, nil
+	return b
 }
 
 func (b *Booster) UpdateOneIter() (bool, error) {
 	return boosterUpdateOneIter(b.ptr)
 }
 
+// UpdateOneIterCustom performs one boosting iteration using the provided gradients and hessians. The
+// booster must have been created with the "objective=none" parameter.
+func (b *Booster) UpdateOneIterCustom(grad []float32, hess []float32) (bool, error) {
+	return boosterUpdateOneIterCustom(b.ptr, grad, hess)
+}
+
+func (b *Booster) UpdateOneIterWithObjective(objective Objective) (bool, error) {
+	if objective == nil {
+		return false, errors.New("no objective function provided")
+	}
+	if b.trainDataset == nil {
+		return false, errors.New("booster has no training data")
+	}
+
+	// Get current predictions and labels of the training data
+	preds, err := b.GetPredict(TrainingDataIndex)
+	if err != nil {
+		return false, err
+	}
+	labels, err := b.trainDataset.getLabels()
+	if err != nil {
+		return false, err
+	}
+
+	// Calculate gradients and hessians
+	grad, hess := objective(preds, labels)
+	if len(grad) != len(preds) || len(hess) != len(preds) {
+		return false, errors.New("objective returned an unexpected number of gradients or hessians")
+	}
+
+	grad32 := make([]float32, len(grad))
+	hess32 := make([]float32, len(hess))
+	for idx := range grad {
+		grad32[idx] = float32(grad[idx])
+		hess32[idx] = float32(hess[idx])
+	}
+
+	// Update
+	return b.UpdateOneIterCustom(grad32, hess32)
+}
+
+func (b *Booster) RollbackOneIter() error {
+	return boosterRollbackOneIter(b.ptr)
+}
+
+func (b *Booster) CurrentIteration() (int, error) {
+	return boosterGetCurrentIteration(b.ptr)
+}
+
+func (b *Booster) NumberOfTotalModel() (int, error) {
+	return boosterNumberOfTotalModel(b.ptr)
+}
+
+func (b *Booster) NumModelPerIteration() (int, error) {
+	return boosterNumModelPerIteration(b.ptr)
+}
+
+func (b *Booster) GetPredict(dataIdx int) ([]float64, error) {
+	return boosterGetPredict(b.ptr, dataIdx)
+}
+
 func (b *Booster) GetEval(dataIdx int) ([]float64, error) {
 	return boosterGetEval(b.ptr, dataIdx)
 }
 
+// GetEvalResults returns the built-in metrics, labeled with their names, followed by the custom ones.
+func (b *Booster) GetEvalResults(dataIdx int) ([]EvalResult, error) {
+	var err error
+
+	// Get metric names
+	if b.evalNames == nil {
+		b.evalNames, err = boosterGetEvalNames(b.ptr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Evaluate
+	values, err := b.GetEval(dataIdx)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(b.evalNames) {
+		return nil, errors.New("the number of metric values does not match the number of names")
+	}
+	customValues, err := b.GetCustomEval(dataIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build results
+	results := make([]EvalResult, 0, len(values)+len(customValues))
+	for idx, value := range values {
+		results = append(results, EvalResult{
+			Name:           b.evalNames[idx],
+			Value:          value,
+			HigherIsBetter: isHigherBetterMetric(b.evalNames[idx]),
+		})
+	}
+	for idx, value := range customValues {
+		results = append(results, EvalResult{
+			Name:           b.customMetrics[idx].Name,
+			Value:          value,
+			HigherIsBetter: b.customMetrics[idx].HigherIsBetter,
+		})
+	}
+
+	// Done
+	return results, nil
+}
+
+func (b *Booster) AddCustomMetric(name string, higherIsBetter bool, fn MetricFunc) error {
+	if len(name) == 0 {
+		return errors.New("empty metric name")
+	}
+	if fn == nil {
+		return errors.New("no metric function provided")
+	}
+	for _, m := range b.customMetrics {
+		if m.Name == name {
+			return errors.New("metric already registered")
+		}
+	}
+
+	b.customMetrics = append(b.customMetrics, CustomMetric{
+		Name:           name,
+		HigherIsBetter: higherIsBetter,
+		Eval:           fn,
+	})
+
+	// Done
+	return nil
+}
+
+func (b *Booster) GetCustomEval(dataIdx int) ([]float64, error) {
+	if len(b.customMetrics) == 0 {
+		return make([]float64, 0), nil
+	}
+
+	// Get the dataset
+	ds := b.getDataset(dataIdx)
+	if ds == nil {
+		return nil, errors.New("invalid data index")
+	}
+
+	// Get current predictions, labels and weights
+	preds, err := b.GetPredict(dataIdx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := ds.getLabels()
+	if err != nil {
+		return nil, err
+	}
+	weights, err := ds.getWeights()
+	if err != nil {
+		return nil, err
+	}
+
+	// Evaluate
+	results := make([]float64, len(b.customMetrics))
+	for idx, m := range b.customMetrics {
+		results[idx] = m.Eval(preds, labels, weights)
+	}
+
+	// Done
+	return results, nil
+}
+
+// BestIteration returns the best iteration found by Train when early stopping is enabled, or zero.
+func (b *Booster) BestIteration() int {
+	return b.bestIteration
+}
+
+// BestScores returns, for each validation dataset passed to Train, the evaluation results at the best
+// iteration.
+func (b *Booster) BestScores() [][]EvalResult {
+	return b.bestScores
+}
+
+func (b *Booster) GetFeatureNames() ([]string, error) {
+	return boosterGetFeatureNames(b.ptr)
+}
+
+// FeatureImportance returns the importance of each feature, sorted from the most to the least important.
+func (b *Booster) FeatureImportance(featureImportance FeatureImportance, numIteration int) ([]FeatureScore, error) {
+	numIteration = b.resolveNumIteration(numIteration)
+
+	// Get names and scores
+	names, err := boosterGetFeatureNames(b.ptr)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := boosterFeatureImportance(b.ptr, numIteration, int(featureImportance))
+	if err != nil {
+		return nil, err
+	}
+	if len(names) != len(scores) {
+		return nil, errors.New("the number of feature scores does not match the number of names")
+	}
+
+	// Build results
+	results := make([]FeatureScore, len(scores))
+	for idx := range scores {
+		results[idx] = FeatureScore{
+			Name:  names[idx],
+			Score: scores[idx],
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	// Done
+	return results, nil
+}
+
 func (b *Booster) ToString(featureImportance FeatureImportance) (string, error) {
-	return boosterSaveModelToString(b.ptr, int(featureImportance))
+	return b.ToStringWithOptions(SaveModelOptions{
+		FeatureImportance: featureImportance,
+	})
+}
+
+func (b *Booster) ToStringWithOptions(opts SaveModelOptions) (string, error) {
+	numIteration, err := b.resolveSaveModelOptions(opts)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := boosterSaveModelToString(b.ptr, opts.StartIteration, numIteration, int(opts.FeatureImportance))
+	if err != nil {
+		return "", err
+	}
+	trailer, err := b.getCategoricalTrailer()
+	if err != nil {
+		return "", err
+	}
+
+	// Done
+	return data + string(trailer), nil
+}
+
+func (b *Booster) SaveModel(filename string, opts SaveModelOptions) error {
+	numIteration, err := b.resolveSaveModelOptions(opts)
+	if err != nil {
+		return err
+	}
+	trailer, err := b.getCategoricalTrailer()
+	if err != nil {
+		return err
+	}
+
+	err = boosterSaveModel(b.ptr, opts.StartIteration, numIteration, int(opts.FeatureImportance), filename)
+	if err != nil {
+		return err
+	}
+
+	// Append the category encoder
+	if len(trailer) > 0 {
+		var f *os.File
+
+		f, err = os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(trailer)
+		closeErr := f.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+
+	// Done
+	return err
+}
+
+func (b *Booster) WriteModel(w io.Writer, opts SaveModelOptions) error {
+	numIteration, err := b.resolveSaveModelOptions(opts)
+	if err != nil {
+		return err
+	}
+	trailer, err := b.getCategoricalTrailer()
+	if err != nil {
+		return err
+	}
+
+	data, err := boosterSaveModelToBytes(b.ptr, opts.StartIteration, numIteration, int(opts.FeatureImportance))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, trailer...))
+
+	// Done
+	return err
+}
+
+func (b *Booster) Predictor(predictType PredictType, parameters []string) (*Predictor, error) {
+	return NewPredictorFromBooster(b, predictType, parameters)
+}
+
+func (b *Booster) PredictorWithOptions(predictType PredictType, parameters []string, opts PredictorOptions) (*Predictor, error) {
+	return NewPredictorFromBoosterWithOptions(b, predictType, parameters, opts)
 }
-func (b *Booster) Predictor(rawScore bool, parameters []string) (*Predictor, error) {
-	return NewPredictorFromBooster(b, rawScore, parameters)
+
+func (b *Booster) resolveSaveModelOptions(opts SaveModelOptions) (int, error) {
+	if opts.StartIteration < 0 {
+		return 0, errors.New("invalid start iteration")
+	}
+	return b.resolveNumIteration(opts.NumIteration), nil
+}
+
+// resolveNumIteration returns the number of iterations to pass to LightGBM. NumIterationBest is
+// math.MinInt32, outside of the values LightGBM accepts, because LightGBM already handles any value
+// less than or equal to zero, like -1, as every iteration.
+func (b *Booster) resolveNumIteration(numIteration int) int {
+	switch {
+	case numIteration == NumIterationBest:
+		return b.bestIteration // Zero if there is no best iteration, which means all
+	case numIteration < 0:
+		return NumIterationAll
+	}
+	return numIteration
+}
+
+func (b *Booster) getDataset(dataIdx int) *Dataset {
+	if dataIdx == TrainingDataIndex {
+		return b.trainDataset
+	}
+	if dataIdx > 0 && dataIdx <= len(b.validDatasetList) {
+		return b.validDatasetList[dataIdx-1]
+	}
+	return nil
 }
 
 func (b *Booster) finalize() {
 	boosterFree(b.ptr)
+	b.trainDataset = nil
 	b.validDatasetList = nil
+	b.customMetrics = nil
+	b.evalNames = nil
+	b.bestScores = nil
+	b.categoryEncoder = nil
+}
+
+func isHigherBetterMetric(name string) bool {
+	for _, prefix := range []string{"auc", "ndcg@", "map@", "average_precision"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
 }
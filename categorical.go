@@ -0,0 +1,364 @@
+package lightgbm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+const (
+	categoricalTrailerPrefix    = "pandas_categorical:"
+	categoricalTrailerChunkSize = 4096
+
+	// Model buffers exported as C strings may end with a NUL character
+	modelTrailingChars = "\r\n\x00"
+)
+
+var categoricalFeatureParameters = []string{
+	"categorical_feature", "cat_feature", "categorical_column", "cat_column", "categorical_features",
+}
+
+// -----------------------------------------------------------------------------
+
+// CategoryEncoder maps string categories to the integer codes LightGBM expects in categorical features.
+// Columns are numbered in the same order as the categorical features of the dataset. The encoder is
+// persisted in the model using the same pandas_categorical trailer used by the Python package.
+type CategoryEncoder struct {
+	categories [][]string
+	codes      []map[string]int
+}
+
+// -----------------------------------------------------------------------------
+
+func NewCategoryEncoder(columnsCount int) *CategoryEncoder {
+	e := &CategoryEncoder{
+		categories: make([][]string, columnsCount),
+		codes:      make([]map[string]int, columnsCount),
+	}
+	for idx := range e.codes {
+		e.codes[idx] = make(map[string]int)
+	}
+
+	// Done
+	return e
+}
+
+func (e *CategoryEncoder) ColumnsCount() int {
+	return len(e.categories)
+}
+
+// Fit returns the code of the category, assigning a new one if the category was not seen before.
+func (e *CategoryEncoder) Fit(column int, category string) (float64, error) {
+	if column < 0 || column >= len(e.categories) {
+		return 0, errors.New("invalid column index")
+	}
+
+	code, ok := e.codes[column][category]
+	if !ok {
+		code = len(e.categories[column])
+		e.categories[column] = append(e.categories[column], category)
+		e.codes[column][category] = code
+	}
+
+	// Done
+	return float64(code), nil
+}
+
+// Encode returns the code of the category or NaN, which LightGBM handles as a missing value, if the
+// category is unknown.
+func (e *CategoryEncoder) Encode(column int, category string) float64 {
+	if column < 0 || column >= len(e.categories) {
+		return math.NaN()
+	}
+	code, ok := e.codes[column][category]
+	if !ok {
+		return math.NaN()
+	}
+	return float64(code)
+}
+
+func (e *CategoryEncoder) Decode(column int, code int) (string, bool) {
+	if column < 0 || column >= len(e.categories) || code < 0 || code >= len(e.categories[column]) {
+		return "", false
+	}
+	return e.categories[column][code], true
+}
+
+func (e *CategoryEncoder) Categories(column int) []string {
+	if column < 0 || column >= len(e.categories) {
+		return nil
+	}
+	categories := make([]string, len(e.categories[column]))
+	copy(categories, e.categories[column])
+	return categories
+}
+
+func (e *CategoryEncoder) MarshalJSON() ([]byte, error) {
+	categories := make([][]string, len(e.categories))
+	for idx, values := range e.categories {
+		categories[idx] = append([]string{}, values...)
+	}
+	return json.Marshal(categories)
+}
+
+// UnmarshalJSON decodes the categories of each column. Besides strings, numbers and booleans are accepted
+// because the Python package stores the categories of pandas columns with their original type.
+func (e *CategoryEncoder) UnmarshalJSON(data []byte) error {
+	var categories [][]interface{}
+
+	err := json.Unmarshal(data, &categories)
+	if err != nil {
+		return err
+	}
+
+	*e = *NewCategoryEncoder(len(categories))
+	for column, values := range categories {
+		for _, value := range values {
+			var category string
+
+			switch v := value.(type) {
+			case string:
+				category = v
+			case float64:
+				category = strconv.FormatFloat(v, 'g', -1, 64)
+			case bool:
+				category = strconv.FormatBool(v)
+			default:
+				return errors.New("unsupported category type")
+			}
+
+			if _, ok := e.codes[column][category]; ok {
+				return errors.New("duplicated category")
+			}
+			_, _ = e.Fit(column, category)
+		}
+	}
+
+	// Done
+	return nil
+}
+
+func (ds *Dataset) SetCategoricalFeatures(indices []int) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be modified")
+	}
+	for _, idx := range indices {
+		if idx < 0 {
+			return errors.New("invalid feature index")
+		}
+	}
+
+	ds.categoricalFeatures = append([]int{}, indices...)
+	ds.categoricalFeatureNames = nil
+
+	// Done
+	return nil
+}
+
+// SetCategoricalFeatureNames marks features as categorical by name. Names are resolved against the names
+// set with SetFeatureNames when the dataset is created.
+func (ds *Dataset) SetCategoricalFeatureNames(names []string) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be modified")
+	}
+
+	ds.categoricalFeatures = nil
+	ds.categoricalFeatureNames = append([]string{}, names...)
+
+	// Done
+	return nil
+}
+
+// getCategoricalParameter resolves and validates the categorical features and returns the parameter to pass
+// when the dataset is created.
+func (ds *Dataset) getCategoricalParameter() (string, error) {
+	indices := ds.categoricalFeatures
+	if ds.categoricalFeatureNames != nil {
+		indices = make([]int, len(ds.categoricalFeatureNames))
+		for idx, name := range ds.categoricalFeatureNames {
+			featureIdx := -1
+			for nameIdx, featureName := range ds.featureNames {
+				if featureName == name {
+					featureIdx = nameIdx
+					break
+				}
+			}
+			if featureIdx < 0 {
+				return "", errors.New("categorical feature " + name + " not found")
+			}
+			indices[idx] = featureIdx
+		}
+	}
+	if len(indices) == 0 {
+		return "", nil
+	}
+
+	// Avoid ambiguous definitions
+	for _, param := range strings.Fields(ds.parameters) {
+		key, _, _ := strings.Cut(param, "=")
+		for _, name := range categoricalFeatureParameters {
+			if key == name {
+				return "", errors.New("categorical features are already defined in the parameters")
+			}
+		}
+	}
+
+	// Check values
+	featuresCount := ds.featuresCount
+	if ds.layout == datasetLayoutSparseRows {
+		featuresCount = ds.getSparseRowsColumnsCount()
+	}
+	columns := make([]string, len(indices))
+	for idx, featureIdx := range indices {
+		if featureIdx >= featuresCount {
+			return "", errors.New("invalid categorical feature index")
+		}
+		if !ds.isCategoricalColumn(featureIdx) {
+			return "", errors.New("categorical feature " + strconv.Itoa(featureIdx) +
+				" contains values that are not non-negative integers")
+		}
+		columns[idx] = strconv.Itoa(featureIdx)
+	}
+
+	// Done
+	return "categorical_feature=" + strings.Join(columns, ","), nil
+}
+
+// isCategoricalColumn checks all the values of the feature are non-negative integers. NaN values are
+// allowed and handled as missing values.
+func (ds *Dataset) isCategoricalColumn(featureIdx int) bool {
+	isValid := func(value float64) bool {
+		return math.IsNaN(value) || (value >= 0 && value <= math.MaxInt32 && value == math.Trunc(value))
+	}
+
+	switch ds.layout {
+	case datasetLayoutDenseRows:
+		for ofs := featureIdx; ofs < len(ds.features); ofs += ds.featuresCount {
+			if !isValid(ds.features[ofs]) {
+				return false
+			}
+		}
+
+	case datasetLayoutDenseColumns:
+		for _, value := range ds.features[featureIdx*ds.featuresRowsCount : (featureIdx+1)*ds.featuresRowsCount] {
+			if !isValid(value) {
+				return false
+			}
+		}
+
+	case datasetLayoutSparseRows:
+		for idx, valueIdx := range ds.sparseIndices {
+			if int(valueIdx) == featureIdx && !isValid(ds.sparseValues[idx]) {
+				return false
+			}
+		}
+
+	case datasetLayoutSparseColumns:
+		if featureIdx+1 >= len(ds.sparseIndPtr) {
+			break
+		}
+		for _, value := range ds.sparseValues[ds.sparseIndPtr[featureIdx]:ds.sparseIndPtr[featureIdx+1]] {
+			if !isValid(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SetCategoryEncoder sets the encoder that is saved along with the model.
+func (b *Booster) SetCategoryEncoder(e *CategoryEncoder) {
+	b.categoryEncoder = e
+}
+
+// CategoryEncoder returns the encoder loaded from, or to be saved with, the model. It is nil if the model
+// has none.
+func (b *Booster) CategoryEncoder() *CategoryEncoder {
+	return b.categoryEncoder
+}
+
+func (b *Booster) getCategoricalTrailer() ([]byte, error) {
+	if b.categoryEncoder == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(b.categoryEncoder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return []byte("\n" + categoricalTrailerPrefix + string(data) + "\n"), nil
+}
+
+// loadCategoricalTrailer returns the encoder stored in the model, if any. The trailer is always the last line
+// of the model.
+func loadCategoricalTrailer(data []byte) (*CategoryEncoder, error) {
+	data = bytes.TrimRight(data, modelTrailingChars)
+	line := data[bytes.LastIndexByte(data, '\n')+1:]
+	if !bytes.HasPrefix(line, []byte(categoricalTrailerPrefix)) {
+		return nil, nil
+	}
+
+	value := bytes.TrimSpace(line[len(categoricalTrailerPrefix):])
+	if string(value) == "null" {
+		return nil, nil
+	}
+
+	e := &CategoryEncoder{}
+	err := json.Unmarshal(value, e)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return e, nil
+}
+
+// loadCategoricalTrailerFromFile reads the file backwards, chunk by chunk, until the last line is found, so
+// large models are not read twice.
+func loadCategoricalTrailerFromFile(filename string) (*CategoryEncoder, error) {
+	var data []byte
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	ofs, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	for ofs > 0 {
+		chunkSize := int64(categoricalTrailerChunkSize)
+		if chunkSize > ofs {
+			chunkSize = ofs
+		}
+		ofs -= chunkSize
+
+		chunk := make([]byte, chunkSize, chunkSize+int64(len(data)))
+		_, err = f.ReadAt(chunk, ofs)
+		if err != nil {
+			return nil, err
+		}
+		data = append(chunk, data...)
+
+		// Stop when the line before the last one starts in the read data
+		if bytes.LastIndexByte(bytes.TrimRight(data, modelTrailingChars), '\n') >= 0 {
+			break
+		}
+	}
+
+	// Done
+	return loadCategoricalTrailer(data)
+}
@@ -0,0 +1,62 @@
+// lgbm2go compiles a LightGBM text model, as saved by Booster.ToString or Booster.SaveModel, into a
+// standalone Go source file.
+//
+// Usage:
+//
+//	lgbm2go -model model.txt -out predict.go [-package model] [-func Predict] [-raw]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mxmauro/lightgbm/textmodel"
+)
+
+// -----------------------------------------------------------------------------
+
+func main() {
+	modelFilename := flag.String("model", "", "LightGBM text model to compile")
+	outFilename := flag.String("out", "", "Output Go source file (defaults to stdout)")
+	packageName := flag.String("package", "model", "Package name of the generated file")
+	funcName := flag.String("func", "Predict", "Name of the generated prediction function")
+	rawScore := flag.Bool("raw", false, "Skip the objective transform")
+	flag.Parse()
+
+	if len(*modelFilename) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(*modelFilename, *outFilename, textmodel.GenerateOptions{
+		PackageName: *packageName,
+		FuncName:    *funcName,
+		RawScore:    *rawScore,
+	})
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "lgbm2go:", err)
+		os.Exit(1)
+	}
+}
+
+func run(modelFilename string, outFilename string, opts textmodel.GenerateOptions) error {
+	m, err := textmodel.ParseFile(modelFilename)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = textmodel.GenerateGo(&buf, m, opts)
+	if err != nil {
+		return err
+	}
+
+	// Done
+	if len(outFilename) == 0 {
+		_, err = buf.WriteTo(os.Stdout)
+		return err
+	}
+	return os.WriteFile(outFilename, buf.Bytes(), 0o644)
+}
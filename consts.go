@@ -1,12 +1,33 @@
 package lightgbm
 
+import (
+	"math"
+)
+
 // -----------------------------------------------------------------------------
 
 type FeatureImportance int
 
 const (
-	FeatureImportanceSplit  FeatureImportance = iota
-	FeatureImportanceSplice FeatureImportance = iota
+	FeatureImportanceSplit FeatureImportance = iota
+	FeatureImportanceGain  FeatureImportance = iota
+
+	// Deprecated: Use FeatureImportanceGain.
+	FeatureImportanceSplice = FeatureImportanceGain
+)
+
+type PredictType int
+
+const (
+	PredictTypeNormal    PredictType = iota
+	PredictTypeRawScore  PredictType = iota
+	PredictTypeLeafIndex PredictType = iota
+	PredictTypeContrib   PredictType = iota
+)
+
+const (
+	NumIterationAll  int = 0
+	NumIterationBest int = math.MinInt32 // Negative values other than this one mean all, as in LightGBM
 )
 
 const (
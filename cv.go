@@ -0,0 +1,343 @@
+package lightgbm
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// -----------------------------------------------------------------------------
+
+const (
+	defaultCVFolds = 5
+)
+
+// -----------------------------------------------------------------------------
+
+type CVOptions struct {
+	NFold int // Defaults to 5
+
+	// Stratified keeps the proportion of each label value in every fold.
+	Stratified bool
+
+	// GroupKFold keeps the rows of each group in the same fold. It is required if the dataset has groups.
+	GroupKFold bool
+
+	Shuffle bool
+	Seed    int64
+
+	NumRounds int
+
+	// EarlyStoppingRounds stops the training if the mean of any metric across folds does not improve in the
+	// given number of rounds. Zero disables early stopping.
+	EarlyStoppingRounds int
+
+	Objective     Objective
+	CustomMetrics []CustomMetric
+}
+
+type CVEvalResult struct {
+	Name           string
+	Mean           float64
+	Stddev         float64
+	HigherIsBetter bool
+}
+
+type CVResult struct {
+	Boosters []*Booster // One per fold
+
+	// EvalResults contains, for every iteration, the mean and standard deviation across folds of each metric
+	// evaluated on the held-out fold.
+	EvalResults [][]CVEvalResult
+
+	BestIteration int // Zero if early stopping is disabled
+}
+
+// -----------------------------------------------------------------------------
+
+// CV runs a k-fold cross-validation. For every fold, a booster is trained on the remaining folds and evaluated
+// on the held-out fold, and the evaluation results are aggregated per iteration.
+func CV(ds *Dataset, parameters []string, opts CVOptions) (*CVResult, error) {
+	if ds == nil {
+		return nil, ErrNotInitialized
+	}
+	if opts.NumRounds <= 0 {
+		return nil, errors.New("number of rounds is not positive")
+	}
+	if opts.NFold == 0 {
+		opts.NFold = defaultCVFolds
+	}
+	if opts.NFold < 2 {
+		return nil, errors.New("at least two folds are required")
+	}
+
+	// Split rows in folds
+	folds, err := ds.makeFolds(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a booster for each fold
+	boosters := make([]*Booster, len(folds))
+	for foldIdx := range folds {
+		var trainDS *Dataset
+		var validDS *Dataset
+
+		trainIndices := make([]int32, 0)
+		for otherIdx, fold := range folds {
+			if otherIdx != foldIdx {
+				trainIndices = append(trainIndices, fold...)
+			}
+		}
+		trainDS, err = ds.Subset(trainIndices, nil)
+		if err != nil {
+			return nil, err
+		}
+		validDS, err = ds.Subset(folds[foldIdx], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		boosters[foldIdx], err = NewBoosterFromDataset(trainDS, parameters, []*Dataset{validDS})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range opts.CustomMetrics {
+			err = boosters[foldIdx].AddCustomMetric(m.Name, m.HigherIsBetter, m.Eval)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Train all the folds in lockstep so the early stopping can use the mean across folds
+	result := &CVResult{
+		Boosters: boosters,
+	}
+	var states []earlyStoppingState
+	var history [][][][]EvalResult
+	if opts.EarlyStoppingRounds > 0 {
+		states = make([]earlyStoppingState, 1)
+	}
+
+	for iteration := 1; iteration <= opts.NumRounds; iteration++ {
+		foldResults := make([][][]EvalResult, len(boosters))
+		finishedCount := 0
+
+		for foldIdx, b := range boosters {
+			var isFinished bool
+
+			// Update one iteration
+			if opts.Objective != nil {
+				isFinished, err = b.UpdateOneIterWithObjective(opts.Objective)
+			} else {
+				isFinished, err = b.UpdateOneIter()
+			}
+			if err != nil {
+				return nil, err
+			}
+			if isFinished {
+				finishedCount += 1
+			}
+
+			// Evaluate the held-out fold
+			foldResults[foldIdx] = make([][]EvalResult, 1)
+			foldResults[foldIdx][0], err = b.GetEvalResults(FirstValidationDataIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if finishedCount == len(boosters) {
+			break
+		}
+
+		// Aggregate
+		evalResults := aggregateCVResults(foldResults)
+		result.EvalResults = append(result.EvalResults, evalResults)
+
+		// Check for early stopping
+		if states != nil {
+			meanResults := make([]EvalResult, len(evalResults))
+			for idx, r := range evalResults {
+				meanResults[idx] = EvalResult{
+					Name:           r.Name,
+					Value:          r.Mean,
+					HigherIsBetter: r.HigherIsBetter,
+				}
+			}
+
+			history = append(history, foldResults)
+			bestIteration, shouldStop := updateEarlyStopping(states, [][]EvalResult{meanResults}, iteration, opts.EarlyStoppingRounds)
+			result.BestIteration = bestIteration
+			if shouldStop {
+				break
+			}
+		}
+	}
+
+	// Record the best iteration in each booster
+	if result.BestIteration > 0 {
+		for foldIdx, b := range boosters {
+			b.bestIteration = result.BestIteration
+			b.bestScores = history[result.BestIteration-1][foldIdx]
+		}
+	}
+
+	// Done
+	return result, nil
+}
+
+func aggregateCVResults(foldResults [][][]EvalResult) []CVEvalResult {
+	evalResults := make([]CVEvalResult, len(foldResults[0][0]))
+	for metricIdx, r := range foldResults[0][0] {
+		mean := 0.0
+		for _, results := range foldResults {
+			mean += results[0][metricIdx].Value
+		}
+		mean /= float64(len(foldResults))
+
+		variance := 0.0
+		for _, results := range foldResults {
+			diff := results[0][metricIdx].Value - mean
+			variance += diff * diff
+		}
+		variance /= float64(len(foldResults))
+
+		evalResults[metricIdx] = CVEvalResult{
+			Name:           r.Name,
+			Mean:           mean,
+			Stddev:         math.Sqrt(variance),
+			HigherIsBetter: r.HigherIsBetter,
+		}
+	}
+	return evalResults
+}
+
+// makeFolds returns the row indices of each fold.
+func (ds *Dataset) makeFolds(opts CVOptions) ([][]int32, error) {
+	var rnd *rand.Rand
+
+	rowsCount, err := ds.NumData()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := ds.GetField("group")
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 && !opts.GroupKFold {
+		return nil, errors.New("datasets with groups require GroupKFold")
+	}
+	if opts.GroupKFold && opts.Stratified {
+		return nil, errors.New("stratified folds cannot be combined with GroupKFold")
+	}
+	if opts.Shuffle {
+		rnd = rand.New(rand.NewSource(opts.Seed))
+	}
+
+	folds := make([][]int32, opts.NFold)
+
+	switch {
+	case opts.GroupKFold:
+		if len(groups) == 0 {
+			return nil, errors.New("GroupKFold requires a dataset with groups")
+		}
+		if len(groups) < opts.NFold {
+			return nil, errors.New("the number of groups is less than the number of folds")
+		}
+
+		// Get the rows of each group
+		groupRows := make([][]int32, len(groups))
+		row := int32(0)
+		for groupIdx, size := range groups {
+			groupRows[groupIdx] = make([]int32, int(size))
+			for idx := range groupRows[groupIdx] {
+				groupRows[groupIdx][idx] = row
+				row += 1
+			}
+		}
+
+		// Assign the groups, largest first unless shuffled, to the fold with fewest rows
+		if rnd != nil {
+			rnd.Shuffle(len(groupRows), func(i, j int) {
+				groupRows[i], groupRows[j] = groupRows[j], groupRows[i]
+			})
+		} else {
+			sort.SliceStable(groupRows, func(i, j int) bool {
+				return len(groupRows[i]) > len(groupRows[j])
+			})
+		}
+		for _, rows := range groupRows {
+			foldIdx := 0
+			for idx := range folds {
+				if len(folds[idx]) < len(folds[foldIdx]) {
+					foldIdx = idx
+				}
+			}
+			folds[foldIdx] = append(folds[foldIdx], rows...)
+		}
+
+	case opts.Stratified:
+		var labels []float64
+
+		labels, err = ds.GetField("label")
+		if err != nil {
+			return nil, err
+		}
+
+		// Group rows by label
+		var labelValues []float64
+		labelRows := make(map[float64][]int32)
+		for row, label := range labels {
+			if _, ok := labelRows[label]; !ok {
+				labelValues = append(labelValues, label)
+			}
+			labelRows[label] = append(labelRows[label], int32(row))
+		}
+		sort.Float64s(labelValues)
+
+		// Deal the rows of each label to the folds
+		foldIdx := 0
+		for _, label := range labelValues {
+			rows := labelRows[label]
+			if rnd != nil {
+				rnd.Shuffle(len(rows), func(i, j int) {
+					rows[i], rows[j] = rows[j], rows[i]
+				})
+			}
+			for _, row := range rows {
+				folds[foldIdx] = append(folds[foldIdx], row)
+				foldIdx = (foldIdx + 1) % opts.NFold
+			}
+		}
+
+	default:
+		rows := make([]int32, rowsCount)
+		for idx := range rows {
+			rows[idx] = int32(idx)
+		}
+		if rnd != nil {
+			rnd.Shuffle(len(rows), func(i, j int) {
+				rows[i], rows[j] = rows[j], rows[i]
+			})
+		}
+
+		// Split in contiguous chunks
+		for foldIdx := range folds {
+			start := foldIdx * rowsCount / opts.NFold
+			end := (foldIdx + 1) * rowsCount / opts.NFold
+			folds[foldIdx] = rows[start:end]
+		}
+	}
+
+	// Check every fold has rows
+	for _, fold := range folds {
+		if len(fold) == 0 {
+			return nil, errors.New("not enough rows for the number of folds")
+		}
+	}
+
+	// Done
+	return folds, nil
+}
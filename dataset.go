@@ -3,6 +3,7 @@ package lightgbm
 import (
 	"errors"
 	"runtime"
+	"sort"
 	"strings"
 	"unsafe"
 )
@@ -13,24 +14,43 @@ const (
 	expansionMultiplier = 10240
 )
 
+type datasetLayout int
+
+const (
+	datasetLayoutNone datasetLayout = iota
+	datasetLayoutDenseRows
+	datasetLayoutDenseColumns
+	datasetLayoutSparseRows
+	datasetLayoutSparseColumns
+)
+
 // -----------------------------------------------------------------------------
 
 type Dataset struct {
-	refDS             *Dataset
-	parameters        string
-	ptr               unsafe.Pointer
-	features          []float64
-	featuresCount     int
-	featuresRowsCount int
-	featureNames      []string
-	groups            []int32
-	groupsCount       int
-	labels            []float32
-	labelsCount       int
-	initScores        []float64
-	initScoresCount   int
-	weights           []float32
-	weightsCount      int
+	refDS                   *Dataset
+	parameters              string
+	ptr                     unsafe.Pointer
+	layout                  datasetLayout
+	features                []float64
+	featuresCount           int
+	featuresRowsCount       int
+	sparseFeaturesCount     int
+	sparseRowsCount         int
+	sparseIndPtr            []int64
+	sparseIndices           []int32
+	sparseValues            []float64
+	featureNames            []string
+	categoricalFeatures     []int
+	categoricalFeatureNames []string
+	groups                  []int32
+	groupsCount             int
+	labels                  []float32
+	labelsCount             int
+	initScores              []float64
+	initScoresCount         int
+	weights                 []float32
+	weightsCount            int
+	stream                  *datasetStream
 }
 
 // -----------------------------------------------------------------------------
@@ -53,10 +73,102 @@ func NewDatasetWithReference(parameters []string, refDS *Dataset) *Dataset {
 	return ds
 }
 
+func NewDatasetFromFile(filename string, parameters []string, refDS *Dataset) (*Dataset, error) {
+	var ref unsafe.Pointer
+	var err error
+
+	// Get the reference dataset handle
+	if refDS != nil {
+		ref, err = refDS.getPtr()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Load the file. Binary files created with SaveBinary are detected automatically. For text files, the
+	// label, weight and group columns, as well as the presence of a header, are taken from the parameters
+	// (label_column, weight_column, group_column, header, ...).
+	params := strings.Join(parameters, " ")
+	datasetPtr, err := datasetCreateFromFile(filename, params, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the dataset object
+	ds := &Dataset{
+		refDS:      refDS,
+		parameters: params,
+		ptr:        datasetPtr,
+	}
+	runtime.SetFinalizer(ds, func(ds *Dataset) {
+		ds.finalize()
+	})
+
+	// Done
+	return ds, nil
+}
+
+// Subset creates a new dataset with the given rows. The subset reuses the bin mappers of this dataset and
+// keeps the labels, weights, init scores and groups of the selected rows. When the dataset has groups, the
+// indices must select whole groups.
+//
+// The rows of the subset are always in ascending order of their index in this dataset, regardless of the
+// order of the given indices.
+func (ds *Dataset) Subset(indices []int32, parameters []string) (*Dataset, error) {
+	// Get the dataset handle
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check indices. LightGBM expects them in ascending order so the subset rows are sorted.
+	rowsCount, err := datasetGetNumData(datasetPtr)
+	if err != nil {
+		return nil, err
+	}
+	sortedIndices := make([]int32, len(indices))
+	copy(sortedIndices, indices)
+	sort.Slice(sortedIndices, func(i, j int) bool {
+		return sortedIndices[i] < sortedIndices[j]
+	})
+	for idx, rowIdx := range sortedIndices {
+		if rowIdx < 0 || int(rowIdx) >= rowsCount {
+			return nil, errors.New("invalid row index")
+		}
+		if idx > 0 && rowIdx == sortedIndices[idx-1] {
+			return nil, errors.New("duplicated row index")
+		}
+	}
+
+	// Create the subset
+	params := strings.Join(parameters, " ")
+	subsetPtr, err := datasetGetSubset(datasetPtr, sortedIndices, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the dataset object
+	subset := &Dataset{
+		parameters:        params,
+		ptr:               subsetPtr,
+		featuresCount:     ds.featuresCount,
+		featuresRowsCount: len(sortedIndices),
+	}
+	runtime.SetFinalizer(subset, func(ds *Dataset) {
+		ds.finalize()
+	})
+
+	// Done
+	return subset, nil
+}
+
 func (ds *Dataset) AddFeatureData(data []float64) error {
 	if ds.ptr != nil {
 		return errors.New("dataset cannot be expanded")
 	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutDenseRows {
+		return errors.New("dataset already contains data in a different layout")
+	}
 
 	// First row?
 	if len(ds.features) == 0 {
@@ -81,6 +193,131 @@ func (ds *Dataset) AddFeatureData(data []float64) error {
 
 	ds.features = append(ds.features, data...)
 	ds.featuresRowsCount += 1
+	ds.layout = datasetLayoutDenseRows
+
+	// Done
+	return nil
+}
+
+func (ds *Dataset) AddSparseRow(indices []int32, values []float64) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be expanded")
+	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutSparseRows {
+		return errors.New("dataset already contains data in a different layout")
+	}
+
+	if ds.sparseFeaturesCount > 0 && len(indices) > 0 && int(indices[len(indices)-1]) >= ds.sparseFeaturesCount {
+		return errors.New("feature index is greater than the number of features")
+	}
+
+	// Add the row
+	maxIdx, err := ds.addSparseVector(indices, values)
+	if err != nil {
+		return err
+	}
+	if maxIdx >= ds.featuresCount {
+		ds.featuresCount = maxIdx + 1
+	}
+	ds.featuresRowsCount += 1
+	ds.layout = datasetLayoutSparseRows
+
+	// Done
+	return nil
+}
+
+// SetFeaturesCount sets the number of features of a dataset built with AddSparseRow. If not set, it is
+// taken from the feature names or, if there are none, from the largest index added, so trailing features
+// without values in any row would be lost.
+func (ds *Dataset) SetFeaturesCount(count int) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be modified")
+	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutSparseRows {
+		return errors.New("the number of features can only be set for sparse rows")
+	}
+	if count <= 0 || count < ds.featuresCount {
+		return errors.New("invalid number of features")
+	}
+
+	ds.sparseFeaturesCount = count
+
+	// Done
+	return nil
+}
+
+func (ds *Dataset) AddFeatureColumn(data []float64) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be expanded")
+	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutDenseColumns {
+		return errors.New("dataset already contains data in a different layout")
+	}
+
+	// First column?
+	if len(ds.features) == 0 {
+		// Check data length
+		if len(data) == 0 {
+			return errors.New("empty data")
+		}
+		ds.featuresRowsCount = len(data)
+	} else {
+		// Check data length
+		if len(data) != ds.featuresRowsCount {
+			return errors.New("columns of data must contain the same amount of rows")
+		}
+	}
+
+	ds.features = append(ds.features, data...)
+	ds.featuresCount += 1
+	ds.layout = datasetLayoutDenseColumns
+
+	// Done
+	return nil
+}
+
+func (ds *Dataset) AddSparseFeatureColumn(indices []int32, values []float64) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be expanded")
+	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutSparseColumns {
+		return errors.New("dataset already contains data in a different layout")
+	}
+
+	if ds.sparseRowsCount > 0 && len(indices) > 0 && int(indices[len(indices)-1]) >= ds.sparseRowsCount {
+		return errors.New("row index is greater than the number of rows")
+	}
+
+	// Add the column
+	maxIdx, err := ds.addSparseVector(indices, values)
+	if err != nil {
+		return err
+	}
+	if maxIdx >= ds.featuresRowsCount {
+		ds.featuresRowsCount = maxIdx + 1
+	}
+	ds.featuresCount += 1
+	ds.layout = datasetLayoutSparseColumns
+
+	// Done
+	return nil
+}
+
+// SetRowsCount sets the number of rows of a dataset built with AddSparseFeatureColumn. If not set, it is
+// taken from the labels or, if there are none, from the largest index added, so trailing rows without values
+// in any column would be lost.
+func (ds *Dataset) SetRowsCount(count int) error {
+	if ds.ptr != nil {
+		return errors.New("dataset cannot be modified")
+	}
+	if ds.layout != datasetLayoutNone && ds.layout != datasetLayoutSparseColumns {
+		return errors.New("the number of rows can only be set for sparse columns")
+	}
+	if count <= 0 || count < ds.featuresRowsCount {
+		return errors.New("invalid number of rows")
+	}
+
+	ds.sparseRowsCount = count
 
 	// Done
 	return nil
@@ -261,28 +498,223 @@ func (ds *Dataset) SetGroups(data []int) error {
 	return nil
 }
 
+func (ds *Dataset) SaveBinary(filename string) error {
+	// Get the dataset handle
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return err
+	}
+
+	// Save it
+	return datasetSaveBinary(datasetPtr, filename)
+}
+
+func (ds *Dataset) NumData() (int, error) {
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return 0, err
+	}
+	return datasetGetNumData(datasetPtr)
+}
+
+func (ds *Dataset) NumFeature() (int, error) {
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return 0, err
+	}
+	return datasetGetNumFeature(datasetPtr)
+}
+
+// FeatureNumBin returns the number of bins LightGBM created for the given feature.
+func (ds *Dataset) FeatureNumBin(featureIdx int) (int, error) {
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return 0, err
+	}
+
+	featuresCount, err := datasetGetNumFeature(datasetPtr)
+	if err != nil {
+		return 0, err
+	}
+	if featureIdx < 0 || featureIdx >= featuresCount {
+		return 0, errors.New("invalid feature index")
+	}
+
+	// Done
+	return datasetGetFeatureNumBin(datasetPtr, featureIdx)
+}
+
+func (ds *Dataset) GetFeatureNames() ([]string, error) {
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return nil, err
+	}
+	return datasetGetFeatureNames(datasetPtr)
+}
+
+// GetField returns the values of the "label", "weight", "init_score" or "group" field stored in the native
+// dataset. The group field is returned as group sizes, like they are passed to SetGroups, instead of the
+// query boundaries LightGBM stores. A nil slice is returned if the field is not set.
+func (ds *Dataset) GetField(field string) ([]float64, error) {
+	datasetPtr, err := ds.getPtr()
+	if err != nil {
+		return nil, err
+	}
+
+	values, intValues, err := datasetGetField(datasetPtr, field)
+	if err != nil {
+		return nil, err
+	}
+	if intValues != nil {
+		if field == "group" {
+			// Convert query boundaries into group sizes
+			values = make([]float64, len(intValues)-1)
+			for idx := range values {
+				values[idx] = float64(intValues[idx+1] - intValues[idx])
+			}
+		} else {
+			values = make([]float64, len(intValues))
+			for idx, value := range intValues {
+				values[idx] = float64(value)
+			}
+		}
+	}
+
+	// Done
+	return values, nil
+}
+
+func (ds *Dataset) getLabels() ([]float64, error) {
+	labels, err := ds.GetField("label")
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("dataset has no labels")
+	}
+
+	// Done
+	return labels, nil
+}
+
+func (ds *Dataset) getWeights() ([]float64, error) {
+	return ds.GetField("weight")
+}
+
+func (ds *Dataset) addSparseVector(indices []int32, values []float64) (int, error) {
+	// Check data
+	if len(indices) != len(values) {
+		return -1, errors.New("indices and values must have the same length")
+	}
+	for idx, valueIdx := range indices {
+		if valueIdx < 0 {
+			return -1, errors.New("negative index")
+		}
+		if idx > 0 && valueIdx <= indices[idx-1] {
+			return -1, errors.New("indices must be in ascending order")
+		}
+	}
+
+	// First vector?
+	if ds.sparseIndPtr == nil {
+		ds.sparseIndPtr = make([]int64, 1, expansionMultiplier)
+	}
+
+	ds.sparseIndices = append(ds.sparseIndices, indices...)
+	ds.sparseValues = append(ds.sparseValues, values...)
+	ds.sparseIndPtr = append(ds.sparseIndPtr, int64(len(ds.sparseValues)))
+
+	// Done
+	if len(indices) == 0 {
+		return -1, nil
+	}
+	return int(indices[len(indices)-1]), nil
+}
+
+func (ds *Dataset) getSparseRowsColumnsCount() int {
+	if ds.sparseFeaturesCount > 0 {
+		return ds.sparseFeaturesCount
+	}
+
+	// Sparse rows may not contain values in the last columns
+	columnsCount := ds.featuresCount
+	if len(ds.featureNames) > columnsCount {
+		columnsCount = len(ds.featureNames)
+	}
+	if ds.refDS != nil && ds.refDS.featuresCount > columnsCount {
+		columnsCount = ds.refDS.featuresCount
+	}
+	return columnsCount
+}
+
 func (ds *Dataset) getPtr() (unsafe.Pointer, error) {
+	var datasetPtr unsafe.Pointer
 	var ref unsafe.Pointer
+	var err error
 
+	if ds.stream != nil {
+		return nil, errors.New("streaming dataset is not finished")
+	}
 	if ds.ptr != nil {
 		return ds.ptr, nil // Already created
 	}
-	if len(ds.features) == 0 {
+	if ds.layout == datasetLayoutNone {
 		return nil, errors.New("dataset has no features")
 	}
 
 	// Create dataset
 	if ds.refDS != nil {
-		ref = ds.refDS.ptr
+		ref, err = ds.refDS.getPtr()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the categorical features to the parameters
+	params := ds.parameters
+	categoricalParam, err := ds.getCategoricalParameter()
+	if err != nil {
+		return nil, err
+	}
+	if len(categoricalParam) > 0 {
+		params = strings.TrimSpace(params + " " + categoricalParam)
+	}
+
+	switch ds.layout {
+	case datasetLayoutDenseRows:
+		datasetPtr, err = datasetCreateFromMat(ds.features, ds.featuresRowsCount, true, params, ref)
+
+	case datasetLayoutDenseColumns:
+		datasetPtr, err = datasetCreateFromMat(ds.features, ds.featuresRowsCount, false, params, ref)
+
+	case datasetLayoutSparseRows:
+		columnsCount := ds.getSparseRowsColumnsCount()
+		datasetPtr, err = datasetCreateFromCSR(ds.sparseIndPtr, ds.sparseIndices, ds.sparseValues, columnsCount, params, ref)
+		if err == nil {
+			ds.featuresCount = columnsCount
+		}
+
+	case datasetLayoutSparseColumns:
+		rowsCount := ds.featuresRowsCount
+		if ds.sparseRowsCount > 0 {
+			rowsCount = ds.sparseRowsCount
+			if ds.labels != nil && len(ds.labels) != rowsCount {
+				return nil, errors.New("the number of labels does not match the number of rows")
+			}
+		} else if len(ds.labels) > rowsCount {
+			rowsCount = len(ds.labels)
+		}
+		datasetPtr, err = datasetCreateFromCSC(ds.sparseIndPtr, ds.sparseIndices, ds.sparseValues, rowsCount, params, ref)
+		if err == nil {
+			ds.featuresRowsCount = rowsCount
+		}
 	}
-	datasetPtr, err := datasetCreateFromMat(ds.features, ds.featuresRowsCount, ds.parameters, ref)
 	if err != nil {
 		return nil, err
 	}
 
 	if ds.featureNames != nil {
-		featuresCount := len(ds.features) / ds.featuresRowsCount
-		if len(ds.featureNames) != featuresCount {
+		if len(ds.featureNames) != ds.featuresCount {
 			datasetFree(datasetPtr)
 			return nil, errors.New("the number of feature columns does not match the number of names")
 		}
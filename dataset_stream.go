@@ -0,0 +1,235 @@
+package lightgbm
+
+import (
+	"errors"
+	"runtime"
+)
+
+// -----------------------------------------------------------------------------
+
+const (
+	defaultStreamChunkSize = 1024
+)
+
+// -----------------------------------------------------------------------------
+
+type StreamingDatasetOptions struct {
+	// ChunkSize is the number of rows buffered before they are pushed to LightGBM. Defaults to 1024.
+	ChunkSize int
+
+	HasWeights    bool
+	HasInitScores bool
+	HasGroups     bool
+
+	// ClassesCount is the number of init scores of each row. Defaults to 1.
+	ClassesCount int
+}
+
+type StreamRow struct {
+	Features   []float64
+	Label      float64
+	Weight     float64
+	InitScores []float64
+
+	// Group is the query id of the row. Rows belonging to the same query must be pushed contiguously.
+	Group int
+}
+
+type datasetStream struct {
+	opts         StreamingDatasetOptions
+	pushedRows   int
+	bufferedRows int
+	features     []float64
+	labels       []float32
+	weights      []float32
+	initScores   []float64
+	queries      []int32
+
+	// Init scores are buffered row by row but LightGBM expects them grouped by class
+	classInitScores []float64
+
+	// err is the error of a failed push. The rows of the chunk are lost so the stream cannot continue.
+	err error
+}
+
+// -----------------------------------------------------------------------------
+
+// NewStreamingDataset creates a dataset of rowsCount rows that are pushed in chunks with PushRow instead of
+// being buffered in Go memory. The bin mappers are taken from the reference dataset, which is usually built
+// from a sample of the data. FinishStreaming must be called after the last row is pushed.
+func NewStreamingDataset(refDS *Dataset, rowsCount int, opts StreamingDatasetOptions) (*Dataset, error) {
+	if refDS == nil {
+		return nil, errors.New("streaming datasets require a reference dataset")
+	}
+	if opts.ChunkSize < 0 || opts.ClassesCount < 0 {
+		return nil, errors.New("invalid streaming options")
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = defaultStreamChunkSize
+	}
+	if opts.ClassesCount == 0 {
+		opts.ClassesCount = 1
+	}
+
+	// Get the reference dataset handle
+	ref, err := refDS.getPtr()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the native dataset and prepare it for streaming
+	datasetPtr, err := datasetCreateByReference(ref, rowsCount)
+	if err != nil {
+		return nil, err
+	}
+	err = datasetInitStreaming(datasetPtr, opts.HasWeights, opts.HasInitScores, opts.HasGroups, opts.ClassesCount)
+	if err == nil {
+		err = datasetSetWaitForManualFinish(datasetPtr, true)
+	}
+	if err != nil {
+		datasetFree(datasetPtr)
+		return nil, err
+	}
+
+	// Create the dataset object
+	ds := &Dataset{
+		refDS:             refDS,
+		parameters:        refDS.parameters,
+		ptr:               datasetPtr,
+		featuresRowsCount: rowsCount,
+		stream: &datasetStream{
+			opts:   opts,
+			labels: make([]float32, 0, opts.ChunkSize),
+		},
+	}
+	runtime.SetFinalizer(ds, func(ds *Dataset) {
+		ds.finalize()
+	})
+
+	// Done
+	return ds, nil
+}
+
+func (ds *Dataset) PushRow(row StreamRow) error {
+	s := ds.stream
+	if s == nil {
+		return errors.New("dataset is not in streaming mode")
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.pushedRows+s.bufferedRows >= ds.featuresRowsCount {
+		return errors.New("all rows of the dataset were already pushed")
+	}
+
+	// First row?
+	if ds.featuresCount == 0 {
+		// Check data length
+		if len(row.Features) == 0 {
+			return errors.New("empty data")
+		}
+		ds.featuresCount = len(row.Features)
+		s.features = make([]float64, 0, ds.featuresCount*s.opts.ChunkSize)
+	} else {
+		// Check data length
+		if len(row.Features) != ds.featuresCount {
+			return errors.New("rows of data must contain the same amount of features")
+		}
+	}
+	if s.opts.HasInitScores && len(row.InitScores) != s.opts.ClassesCount {
+		return errors.New("the number of init scores does not match the number of classes")
+	}
+
+	// Buffer the row
+	s.features = append(s.features, row.Features...)
+	s.labels = append(s.labels, float32(row.Label))
+	if s.opts.HasWeights {
+		s.weights = append(s.weights, float32(row.Weight))
+	}
+	if s.opts.HasInitScores {
+		s.initScores = append(s.initScores, row.InitScores...)
+	}
+	if s.opts.HasGroups {
+		s.queries = append(s.queries, int32(row.Group))
+	}
+	s.bufferedRows += 1
+
+	// Push the chunk if full
+	if s.bufferedRows == s.opts.ChunkSize {
+		return ds.flushStream()
+	}
+
+	// Done
+	return nil
+}
+
+// FinishStreaming pushes the buffered rows and completes the construction of the dataset.
+func (ds *Dataset) FinishStreaming() error {
+	s := ds.stream
+	if s == nil {
+		return errors.New("dataset is not in streaming mode")
+	}
+	if s.err != nil {
+		return s.err
+	}
+
+	// Push the remaining rows
+	if s.bufferedRows > 0 {
+		err := ds.flushStream()
+		if err != nil {
+			return err
+		}
+	}
+	if s.pushedRows != ds.featuresRowsCount {
+		return errors.New("the number of pushed rows does not match the number of rows of the dataset")
+	}
+
+	// Finish loading
+	err := datasetMarkFinished(ds.ptr)
+	if err != nil {
+		return err
+	}
+	ds.stream = nil
+
+	// Done
+	return nil
+}
+
+func (ds *Dataset) flushStream() error {
+	var initScores []float64
+
+	s := ds.stream
+	rowsCount := s.bufferedRows
+
+	// Group init scores by class
+	if s.opts.HasInitScores {
+		if s.classInitScores == nil {
+			s.classInitScores = make([]float64, s.opts.ClassesCount*s.opts.ChunkSize)
+		}
+		initScores = s.classInitScores[:s.opts.ClassesCount*rowsCount]
+		for row := 0; row < rowsCount; row++ {
+			for class := 0; class < s.opts.ClassesCount; class++ {
+				initScores[class*rowsCount+row] = s.initScores[row*s.opts.ClassesCount+class]
+			}
+		}
+	}
+
+	// Push the chunk
+	err := datasetPushRowsWithMetadata(ds.ptr, s.features, rowsCount, s.pushedRows, s.labels, s.weights, initScores, s.queries)
+	if err != nil {
+		s.err = err
+		return err
+	}
+	s.pushedRows += rowsCount
+
+	// Reuse the buffers for the next chunk
+	s.bufferedRows = 0
+	s.features = s.features[:0]
+	s.labels = s.labels[:0]
+	s.weights = s.weights[:0]
+	s.initScores = s.initScores[:0]
+	s.queries = s.queries[:0]
+
+	// Done
+	return nil
+}
@@ -0,0 +1,8 @@
+package lightgbm
+
+// -----------------------------------------------------------------------------
+
+// FreeDatasetHandle releases the native dataset so tests can check how failed native calls are handled.
+func FreeDatasetHandle(ds *Dataset) {
+	ds.finalize()
+}
@@ -64,6 +64,49 @@ func loadLib(path string) error {
 		getProc("LGBM_BoosterPredictForMatSingleRowFastInit"),
 		getProc("LGBM_BoosterPredictForMatSingleRowFast"),
 		getProc("LGBM_FastConfigFree"),
+
+		getProc("LGBM_BoosterCalcNumPredict"),
+		getProc("LGBM_BoosterPredictForMat"),
+
+		getProc("LGBM_DatasetCreateFromCSR"),
+
+		getProc("LGBM_DatasetCreateFromCSC"),
+
+		getProc("LGBM_DatasetCreateFromFile"),
+
+		getProc("LGBM_DatasetSaveBinary"),
+
+		getProc("LGBM_BoosterUpdateOneIterCustom"),
+		getProc("LGBM_BoosterGetPredict"),
+		getProc("LGBM_DatasetGetField"),
+
+		getProc("LGBM_BoosterGetEvalNames"),
+
+		getProc("LGBM_BoosterGetCurrentIteration"),
+		getProc("LGBM_BoosterRollbackOneIter"),
+		getProc("LGBM_BoosterNumberOfTotalModel"),
+		getProc("LGBM_BoosterNumModelPerIteration"),
+
+		getProc("LGBM_BoosterCreateFromModelfile"),
+		getProc("LGBM_BoosterSaveModel"),
+
+		getProc("LGBM_BoosterDumpModel"),
+
+		getProc("LGBM_BoosterFeatureImportance"),
+		getProc("LGBM_BoosterGetFeatureNames"),
+
+		getProc("LGBM_DatasetCreateByReference"),
+		getProc("LGBM_DatasetInitStreaming"),
+		getProc("LGBM_DatasetPushRowsWithMetadata"),
+		getProc("LGBM_DatasetMarkFinished"),
+		getProc("LGBM_DatasetSetWaitForManualFinish"),
+
+		getProc("LGBM_DatasetGetNumData"),
+		getProc("LGBM_DatasetGetNumFeature"),
+		getProc("LGBM_DatasetGetFeatureNumBin"),
+		getProc("LGBM_DatasetGetFeatureNames"),
+
+		getProc("LGBM_DatasetGetSubset"),
 	)
 
 	// Done
@@ -0,0 +1,213 @@
+package lightgbm
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// Model is the typed representation of the JSON dump of a booster.
+type Model struct {
+	Name                string                 `json:"name"`
+	Version             string                 `json:"version"`
+	NumClass            int                    `json:"num_class"`
+	NumTreePerIteration int                    `json:"num_tree_per_iteration"`
+	LabelIndex          int                    `json:"label_index"`
+	MaxFeatureIdx       int                    `json:"max_feature_idx"`
+	Objective           string                 `json:"objective"`
+	AverageOutput       bool                   `json:"average_output"`
+	FeatureNames        []string               `json:"feature_names"`
+	MonotoneConstraints []int                  `json:"monotone_constraints"`
+	FeatureInfos        map[string]FeatureInfo `json:"feature_infos"`
+	Trees               []Tree                 `json:"tree_info"`
+	FeatureImportances  map[string]float64     `json:"feature_importances"`
+}
+
+type FeatureInfo struct {
+	MinValue float64 `json:"min_value"`
+	MaxValue float64 `json:"max_value"`
+	Values   []int   `json:"values"`
+}
+
+type Tree struct {
+	TreeIndex int     `json:"tree_index"`
+	NumLeaves int     `json:"num_leaves"`
+	NumCat    int     `json:"num_cat"`
+	Shrinkage float64 `json:"shrinkage"`
+	Root      Node    `json:"-"`
+}
+
+// Node is either a *SplitNode or a *LeafNode.
+type Node interface {
+	IsLeaf() bool
+}
+
+type SplitNode struct {
+	SplitIndex     int     `json:"split_index"`
+	SplitFeature   int     `json:"split_feature"`
+	SplitGain      float64 `json:"split_gain"`
+	DecisionType   string  `json:"decision_type"`
+	DefaultLeft    bool    `json:"default_left"`
+	MissingType    string  `json:"missing_type"`
+	InternalValue  float64 `json:"internal_value"`
+	InternalWeight float64 `json:"internal_weight"`
+	InternalCount  int     `json:"internal_count"`
+
+	// Threshold is set for numerical splits (decision type "<=") and Categories for categorical ones
+	// (decision type "==").
+	Threshold  float64 `json:"-"`
+	Categories []int   `json:"-"`
+
+	Left  Node `json:"-"`
+	Right Node `json:"-"`
+}
+
+type LeafNode struct {
+	LeafIndex  int     `json:"leaf_index"`
+	LeafValue  float64 `json:"leaf_value"`
+	LeafWeight float64 `json:"leaf_weight"`
+	LeafCount  int     `json:"leaf_count"`
+
+	// Only set for linear trees.
+	LeafConst    float64   `json:"leaf_const"`
+	LeafFeatures []int     `json:"leaf_features"`
+	LeafCoeff    []float64 `json:"leaf_coeff"`
+}
+
+// -----------------------------------------------------------------------------
+
+func (b *Booster) DumpModel(opts SaveModelOptions) (*Model, error) {
+	data, err := b.DumpModelJSON(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Model{}
+	err = json.Unmarshal(data, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return m, nil
+}
+
+func (b *Booster) DumpModelJSON(opts SaveModelOptions) ([]byte, error) {
+	numIteration, err := b.resolveSaveModelOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return boosterDumpModel(b.ptr, opts.StartIteration, numIteration, int(opts.FeatureImportance))
+}
+
+// Walk calls fn for every node of the tree in depth-first order. Walking stops if fn returns false.
+func (t *Tree) Walk(fn func(node Node, depth int) bool) {
+	if t.Root != nil {
+		walkNode(t.Root, 0, fn)
+	}
+}
+
+func (t *Tree) UnmarshalJSON(data []byte) error {
+	type treeAlias Tree
+
+	var raw struct {
+		treeAlias
+		TreeStructure json.RawMessage `json:"tree_structure"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*t = Tree(raw.treeAlias)
+
+	t.Root, err = unmarshalNode(raw.TreeStructure)
+	return err
+}
+
+func (*SplitNode) IsLeaf() bool {
+	return false
+}
+
+func (*LeafNode) IsLeaf() bool {
+	return true
+}
+
+func walkNode(node Node, depth int, fn func(node Node, depth int) bool) bool {
+	if !fn(node, depth) {
+		return false
+	}
+	if split, ok := node.(*SplitNode); ok {
+		return walkNode(split.Left, depth+1, fn) && walkNode(split.Right, depth+1, fn)
+	}
+	return true
+}
+
+func unmarshalNode(data json.RawMessage) (Node, error) {
+	var fields map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leaf?
+	if _, ok := fields["left_child"]; !ok {
+		leaf := &LeafNode{}
+		err = json.Unmarshal(data, leaf)
+		if err != nil {
+			return nil, err
+		}
+		return leaf, nil
+	}
+
+	split := &SplitNode{}
+	err = json.Unmarshal(data, split)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse threshold
+	threshold, ok := fields["threshold"]
+	if !ok {
+		return nil, errors.New("split node without threshold")
+	}
+	if split.DecisionType == "==" {
+		var categories string
+
+		err = json.Unmarshal(threshold, &categories)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range strings.Split(categories, "||") {
+			var value int
+
+			value, err = strconv.Atoi(category)
+			if err != nil {
+				return nil, err
+			}
+			split.Categories = append(split.Categories, value)
+		}
+	} else {
+		err = json.Unmarshal(threshold, &split.Threshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse children
+	split.Left, err = unmarshalNode(fields["left_child"])
+	if err != nil {
+		return nil, err
+	}
+	split.Right, err = unmarshalNode(fields["right_child"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return split, nil
+}
@@ -1,12 +1,21 @@
 package lightgbm_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/mxmauro/lightgbm"
+	"github.com/mxmauro/lightgbm/textmodel"
 )
 
 // -----------------------------------------------------------------------------
@@ -61,6 +70,1513 @@ func TestLoadSave(t *testing.T) {
 	runPrediction(t, b, testData)
 }
 
+func TestSparseDataset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	t.Log("Creating sparse training dataset")
+	ds := lightgbm.NewDataset(nil)
+
+	t.Log("Adding sparse training data")
+	for _, data := range trainData.Features {
+		indices := make([]int32, 0, len(data))
+		values := make([]float64, 0, len(data))
+		for idx, value := range data {
+			if value != 0 {
+				indices = append(indices, int32(idx))
+				values = append(values, value)
+			}
+		}
+		err := ds.AddSparseRow(indices, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Log("Setting feature names")
+	err := ds.SetFeatureNames(trainData.FeatureNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Adding training labels")
+	err = ds.SetLabels(trainData.Labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := trainBooster(t, "regression", ds)
+
+	runPrediction(t, b, testData)
+
+	t.Log("Checking the number of features of sparse rows can be set")
+	ds = lightgbm.NewDataset(nil)
+	err = ds.SetFeaturesCount(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx := 0; idx < 100; idx++ {
+		err = ds.AddSparseRow([]int32{0}, []float64{float64(idx)})
+		if err == nil {
+			err = ds.SetLabel(float64(idx % 2))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ds.AddSparseRow([]int32{3}, []float64{1}) == nil {
+		t.Fatal("an index greater than the number of features was accepted")
+	}
+	featuresCount, err := ds.NumFeature()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if featuresCount != 3 {
+		t.Fatal("unexpected number of features", featuresCount)
+	}
+}
+
+func TestColumnDataset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	t.Log("Creating column-major training dataset")
+	ds := lightgbm.NewDataset(nil)
+
+	t.Log("Adding training data columns")
+	for featureIdx := range trainData.FeatureNames {
+		column := make([]float64, len(trainData.Features))
+		for rowIdx, data := range trainData.Features {
+			column[rowIdx] = data[featureIdx]
+		}
+		err := ds.AddFeatureColumn(column)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Log("Setting feature names")
+	err := ds.SetFeatureNames(trainData.FeatureNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Adding training labels")
+	err = ds.SetLabels(trainData.Labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := trainBooster(t, "regression", ds)
+
+	runPrediction(t, b, testData)
+
+	t.Log("Checking the number of rows of sparse columns can be set")
+	newSparseColumnsDataset := func() *lightgbm.Dataset {
+		ds := lightgbm.NewDataset(nil)
+		err := ds.SetRowsCount(100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for featureIdx := 0; featureIdx < 2; featureIdx++ {
+			// The last rows have no values in any column
+			indices := make([]int32, 50)
+			values := make([]float64, 50)
+			for idx := range indices {
+				indices[idx] = int32(idx)
+				values[idx] = float64(idx*(featureIdx+1) + 1)
+			}
+			err = ds.AddSparseFeatureColumn(indices, values)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return ds
+	}
+	ds = newSparseColumnsDataset()
+	if ds.AddSparseFeatureColumn([]int32{100}, []float64{1}) == nil {
+		t.Fatal("an index greater than the number of rows was accepted")
+	}
+	rowsCount, err := ds.NumData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsCount != 100 {
+		t.Fatal("unexpected number of rows", rowsCount)
+	}
+
+	ds = newSparseColumnsDataset()
+	err = ds.SetLabels(make([]float64, 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ds.NumData()
+	if err == nil {
+		t.Fatal("labels not matching the number of rows were accepted")
+	}
+}
+
+func TestFileDataset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	t.Log("Writing training data to a CSV file")
+	filename := filepath.Join(t.TempDir(), "train.csv")
+	writeCSV(t, filename, trainData)
+
+	t.Log("Creating training dataset from file")
+	ds, err := lightgbm.NewDatasetFromFile(filename, []string{"header=true", "label_column=name:label"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := trainBooster(t, "regression", ds)
+
+	runPrediction(t, b, testData)
+}
+
+func TestBinaryDataset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+
+	t.Log("Saving dataset in binary format")
+	filename := filepath.Join(t.TempDir(), "train.bin")
+	err := ds.SaveBinary(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Loading dataset from binary file")
+	ds, err = lightgbm.NewDatasetFromFile(filename, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := trainBooster(t, "regression", ds)
+
+	runPrediction(t, b, testData)
+}
+
+func TestStreamingDataset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	t.Log("Creating reference dataset from a sample")
+	refDS := createDataset(t, &TestData{
+		Features:     trainData.Features[:500],
+		Labels:       trainData.Labels[:500],
+		FeatureNames: trainData.FeatureNames,
+	})
+
+	t.Log("Streaming training data")
+	ds, err := lightgbm.NewStreamingDataset(refDS, len(trainData.Features), lightgbm.StreamingDatasetOptions{
+		ChunkSize:  128,
+		HasWeights: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, features := range trainData.Features {
+		err = ds.PushRow(lightgbm.StreamRow{
+			Features: features,
+			Label:    trainData.Labels[idx],
+			Weight:   1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ds.PushRow(lightgbm.StreamRow{
+		Features: trainData.Features[0],
+	})
+	if err == nil {
+		t.Fatal("pushing more rows than expected was accepted")
+	}
+	err = ds.FinishStreaming()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := trainBooster(t, "classification", ds)
+
+	runPrediction(t, b, testData)
+
+	t.Log("Checking a failed push stops the stream")
+	ds, err = lightgbm.NewStreamingDataset(refDS, 10, lightgbm.StreamingDatasetOptions{
+		ChunkSize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lightgbm.FreeDatasetHandle(ds)
+	row := lightgbm.StreamRow{
+		Features: trainData.Features[0],
+	}
+	err = ds.PushRow(row)
+	if err != nil {
+		t.Fatal(err) // The first row is only buffered
+	}
+	pushErr := ds.PushRow(row)
+	if pushErr == nil {
+		t.Fatal("pushing a chunk to a released dataset succeeded")
+	}
+	if ds.PushRow(row) != pushErr || ds.FinishStreaming() != pushErr {
+		t.Fatal("the stream continued after a failed push")
+	}
+}
+
+func TestCategoricalFeatures(t *testing.T) {
+	initLogging(t)
+
+	colors := []string{"red", "green", "blue", "yellow", "black"}
+	encoder := lightgbm.NewCategoryEncoder(1)
+
+	t.Log("Creating dataset with a categorical feature")
+	ds := lightgbm.NewDataset([]string{"min_data_per_group=10"})
+	for idx := 0; idx < 1000; idx++ {
+		color := colors[rand.Intn(len(colors))]
+		code, err := encoder.Fit(0, color)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value := rand.Float64()
+		err = ds.AddFeatureData([]float64{code, value})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only some colors are positive, and they are not contiguous codes
+		label := 0.0
+		if color == "green" || color == "black" {
+			label = 1.0
+		}
+		err = ds.SetLabel(label)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := ds.SetFeatureNames([]string{"color", "value"})
+	if err == nil {
+		err = ds.SetCategoricalFeatureNames([]string{"color"})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := lightgbm.Train(ds, []string{"objective=binary", "verbosity=-1"}, lightgbm.TrainOptions{
+		NumRounds: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetCategoryEncoder(encoder)
+
+	t.Log("Checking the model uses categorical splits")
+	m, err := b.DumpModel(lightgbm.SaveModelOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	m.Trees[0].Walk(func(node lightgbm.Node, _ int) bool {
+		if split, ok := node.(*lightgbm.SplitNode); ok && split.DecisionType == "==" {
+			found = true
+		}
+		return !found
+	})
+	if !found {
+		t.Fatal("no categorical split found")
+	}
+
+	t.Log("Checking the category encoder is saved with the model")
+	modelStr, err := b.ToString(lightgbm.FeatureImportanceSplit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = lightgbm.NewBoosterFromString(modelStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.CategoryEncoder() == nil || b.CategoryEncoder().Encode(0, "blue") != encoder.Encode(0, "blue") {
+		t.Fatal("category encoder not restored")
+	}
+
+	p, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	predictions, err := p.Predict([]float64{b.CategoryEncoder().Encode(0, "black"), 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if predictions[0] < 0.5 {
+		t.Fatal("unexpected prediction for a positive category")
+	}
+
+	t.Log("Checking invalid categorical values are rejected")
+	ds = lightgbm.NewDataset(nil)
+	for _, value := range []float64{0, 1, -2, 3} {
+		err = ds.AddFeatureData([]float64{value, 1})
+		if err == nil {
+			err = ds.SetLabel(0)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ds.SetCategoricalFeatures([]int{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = lightgbm.NewBoosterFromDataset(ds, []string{"objective=binary"}, nil)
+	if err == nil {
+		t.Fatal("negative categorical values were accepted")
+	}
+}
+
+func TestCategoryEncoder(t *testing.T) {
+	e := lightgbm.NewCategoryEncoder(2)
+	for _, category := range []string{"b", "a", "b", "c"} {
+		_, err := e.Fit(0, category)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if e.Encode(0, "a") != 1 || e.Encode(0, "c") != 2 || !math.IsNaN(e.Encode(0, "unknown")) {
+		t.Fatal("unexpected codes")
+	}
+	if _, err := e.Fit(2, "a"); err == nil {
+		t.Fatal("an invalid column was accepted")
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[["b","a","c"],[]]` {
+		t.Fatal("unexpected JSON", string(data))
+	}
+
+	var decoded lightgbm.CategoryEncoder
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category, ok := decoded.Decode(0, 2); !ok || category != "c" || decoded.ColumnsCount() != 2 {
+		t.Fatal("unexpected decoded encoder")
+	}
+
+	// The Python package keeps the original type of the categories
+	err = json.Unmarshal([]byte(`[[1, 2.5, 10], ["x", true]]`), &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Encode(0, "2.5") != 1 || decoded.Encode(0, "10") != 2 || decoded.Encode(1, "true") != 1 {
+		t.Fatal("unexpected codes for numeric categories")
+	}
+}
+
+func TestNumericCategoricalTrailer(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Appending a trailer with numeric categories")
+	modelStr, err := b.ToString(lightgbm.FeatureImportanceSplit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	categories := make([]string, 2000) // Larger than the chunks used to read the trailer from files
+	for idx := range categories {
+		categories[idx] = strconv.Itoa(idx * 5)
+	}
+	modelStr += "\npandas_categorical:[[" + strings.Join(categories, ", ") + "]]\n"
+
+	checkEncoder := func(b *lightgbm.Booster) {
+		e := b.CategoryEncoder()
+		if e == nil || e.Encode(0, "0") != 0 || e.Encode(0, "9995") != 1999 {
+			t.Fatal("numeric categories not restored")
+		}
+		runPrediction(t, b, testData)
+	}
+
+	t.Log("Creating new booster from string")
+	b2, err := lightgbm.NewBoosterFromString(modelStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEncoder(b2)
+
+	t.Log("Creating new booster from file")
+	filename := filepath.Join(t.TempDir(), "model.txt")
+	err = os.WriteFile(filename, []byte(modelStr), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b3, err := lightgbm.NewBoosterFromFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEncoder(b3)
+
+	t.Log("Creating new booster from reader")
+	b4, err := lightgbm.NewBoosterFromReader(strings.NewReader(modelStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEncoder(b4)
+
+	t.Log("Creating new booster from a NUL terminated buffer")
+	b5, err := lightgbm.NewBoosterFromReader(strings.NewReader(modelStr + "\x00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEncoder(b5)
+}
+
+func TestDatasetReadBack(t *testing.T) {
+	initLogging(t)
+
+	trainData, _ := generateTestData(1000, 4, "classification", 0)
+
+	ds := createDataset(t, trainData)
+	for idx := range trainData.Labels {
+		err := ds.SetWeight(float64(idx%3 + 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := ds.SetGroups([]int{400, 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Checking dataset shape")
+	rowsCount, err := ds.NumData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	featuresCount, err := ds.NumFeature()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsCount != len(trainData.Features) || featuresCount != len(trainData.FeatureNames) {
+		t.Fatal("unexpected dataset shape", rowsCount, featuresCount)
+	}
+	binsCount, err := ds.FeatureNumBin(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binsCount < 2 {
+		t.Fatal("unexpected number of bins", binsCount)
+	}
+	_, err = ds.FeatureNumBin(featuresCount)
+	if err == nil {
+		t.Fatal("an invalid feature index was accepted")
+	}
+	names, err := ds.GetFeatureNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != strings.Join(trainData.FeatureNames, ",") {
+		t.Fatal("unexpected feature names", names)
+	}
+
+	t.Log("Checking dataset fields")
+	labels, err := ds.GetField("label")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights, err := ds.GetField("weight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != rowsCount || len(weights) != rowsCount {
+		t.Fatal("unexpected number of labels or weights")
+	}
+	for idx := range labels {
+		if labels[idx] != trainData.Labels[idx] || weights[idx] != float64(idx%3+1) {
+			t.Fatal("unexpected label or weight at row", idx)
+		}
+	}
+	groups, err := ds.GetField("group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0] != 400 || groups[1] != 600 {
+		t.Fatal("unexpected groups", groups)
+	}
+	initScores, err := ds.GetField("init_score")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initScores != nil {
+		t.Fatal("unexpected init scores")
+	}
+}
+
+func TestDatasetSubset(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	ds := createDataset(t, trainData)
+	for idx := range trainData.Labels {
+		err := ds.SetWeight(float64(idx%3 + 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Log("Creating subset with the even rows")
+	indices := make([]int32, 0, len(trainData.Labels)/2)
+	for idx := len(trainData.Labels) - 2; idx >= 0; idx -= 2 {
+		indices = append(indices, int32(idx)) // Unsorted on purpose
+	}
+	subset, err := ds.Subset(indices, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowsCount, err := subset.NumData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsCount != len(indices) {
+		t.Fatal("unexpected number of rows", rowsCount)
+	}
+	labels, err := subset.GetField("label")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights, err := subset.GetField("weight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx := range labels {
+		if labels[idx] != trainData.Labels[idx*2] || weights[idx] != float64(idx*2%3+1) {
+			t.Fatal("unexpected label or weight at row", idx)
+		}
+	}
+
+	_, err = ds.Subset([]int32{0, 0}, nil)
+	if err == nil {
+		t.Fatal("duplicated indices were accepted")
+	}
+	_, err = ds.Subset([]int32{int32(len(trainData.Labels))}, nil)
+	if err == nil {
+		t.Fatal("an out of range index was accepted")
+	}
+
+	b := trainBooster(t, "classification", subset)
+
+	runPrediction(t, b, testData)
+
+	t.Log("Creating subset of whole groups")
+	ds = createDataset(t, trainData)
+	groups := make([]int, len(trainData.Labels)/10)
+	for idx := range groups {
+		groups[idx] = 10
+	}
+	err = ds.SetGroups(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indices = make([]int32, 50)
+	for idx := range indices {
+		indices[idx] = int32(idx)
+	}
+	subset, err = ds.Subset(indices, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subsetGroups, err := subset.GetField("group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subsetGroups) != 5 || subsetGroups[0] != 10 {
+		t.Fatal("unexpected groups", subsetGroups)
+	}
+}
+
+func TestCV(t *testing.T) {
+	initLogging(t)
+
+	trainData, _ := generateTestData(2000, 4, "classification", 0)
+
+	t.Log("Running stratified cross-validation")
+	ds := createDataset(t, trainData)
+	result, err := lightgbm.CV(ds, []string{"objective=binary", "metric=binary_logloss,auc", "verbosity=-1"}, lightgbm.CVOptions{
+		NFold:               3,
+		Stratified:          true,
+		Shuffle:             true,
+		Seed:                42,
+		NumRounds:           200,
+		EarlyStoppingRounds: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Boosters) != 3 {
+		t.Fatal("unexpected number of boosters")
+	}
+	if len(result.EvalResults) == 0 || result.BestIteration <= 0 || result.BestIteration > len(result.EvalResults) {
+		t.Fatal("unexpected best iteration", result.BestIteration, len(result.EvalResults))
+	}
+	for _, evalResults := range result.EvalResults {
+		if len(evalResults) != 2 || evalResults[0].Name != "binary_logloss" || evalResults[1].Name != "auc" {
+			t.Fatal("unexpected evaluation results", evalResults)
+		}
+		if evalResults[0].Stddev < 0 || !evalResults[1].HigherIsBetter {
+			t.Fatal("unexpected evaluation result values", evalResults)
+		}
+	}
+	for _, b := range result.Boosters {
+		if b.BestIteration() != result.BestIteration {
+			t.Fatal("best iteration not recorded in the booster")
+		}
+	}
+
+	t.Log("Running group cross-validation")
+	ds = createDataset(t, trainData)
+	groups := make([]int, len(trainData.Labels)/20)
+	for idx := range groups {
+		groups[idx] = 20
+	}
+	err = ds.SetGroups(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = lightgbm.CV(ds, []string{"objective=lambdarank", "verbosity=-1"}, lightgbm.CVOptions{
+		NFold:     4,
+		NumRounds: 5,
+	})
+	if err == nil {
+		t.Fatal("a dataset with groups was split without GroupKFold")
+	}
+	result, err = lightgbm.CV(ds, []string{"objective=lambdarank", "metric=ndcg", "eval_at=5", "verbosity=-1"}, lightgbm.CVOptions{
+		NFold:      4,
+		GroupKFold: true,
+		NumRounds:  5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Boosters) != 4 || len(result.EvalResults) != 5 || result.BestIteration != 0 {
+		t.Fatal("unexpected group cross-validation result")
+	}
+}
+
+func TestCustomObjective(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+
+	t.Log("Creating booster from dataset")
+	b, err := lightgbm.NewBoosterFromDataset(ds, []string{
+		"objective=none",
+		"metric=rmse",
+		"num_leaves=31",
+		"learning_rate=0.1",
+		"min_child_samples=20",
+		"verbosity=1",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Updating booster with a squared error objective")
+	squaredError := func(preds []float64, labels []float64) ([]float64, []float64) {
+		grad := make([]float64, len(preds))
+		hess := make([]float64, len(preds))
+		for idx := range preds {
+			grad[idx] = preds[idx] - labels[idx]
+			hess[idx] = 1
+		}
+		return grad, hess
+	}
+	for i := 0; i < 100; i++ {
+		var isFinished bool
+
+		isFinished, err = b.UpdateOneIterWithObjective(squaredError)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isFinished {
+			break
+		}
+	}
+
+	runPrediction(t, b, testData)
+}
+
+func TestCustomMetric(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+	validDS := createDatasetWithReference(t, testData, ds)
+
+	t.Log("Creating booster from dataset")
+	b, err := lightgbm.NewBoosterFromDataset(ds, []string{
+		"objective=regression",
+		"metric=rmse",
+		"num_leaves=31",
+		"learning_rate=0.1",
+		"min_child_samples=20",
+		"verbosity=1",
+	}, []*lightgbm.Dataset{validDS})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Registering mean absolute error metric")
+	err = b.AddCustomMetric("mae", false, func(preds []float64, labels []float64, _ []float64) float64 {
+		sum := 0.0
+		for idx := range preds {
+			sum += math.Abs(preds[idx] - labels[idx])
+		}
+		return sum / float64(len(preds))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Updating booster")
+	lastMAE := math.Inf(1)
+	for i := 0; i < 20; i++ {
+		var results []float64
+
+		_, err = b.UpdateOneIter()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err = b.GetCustomEval(lightgbm.FirstValidationDataIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 {
+			t.Fatal("unexpected number of custom metric results")
+		}
+		if results[0] > lastMAE {
+			t.Fatal("validation error increased during the first iterations")
+		}
+		lastMAE = results[0]
+	}
+}
+
+func TestNamedEvalResults(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	ds := createDataset(t, trainData)
+	validDS := createDatasetWithReference(t, testData, ds)
+
+	t.Log("Creating booster from dataset")
+	b, err := lightgbm.NewBoosterFromDataset(ds, []string{
+		"objective=binary",
+		"metric=binary_logloss,auc",
+		"num_leaves=31",
+		"learning_rate=0.1",
+		"verbosity=1",
+	}, []*lightgbm.Dataset{validDS})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.UpdateOneIter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Getting named evaluation results")
+	results, err := b.GetEvalResults(lightgbm.FirstValidationDataIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatal("unexpected number of evaluation results")
+	}
+	if results[0].Name != "binary_logloss" || results[0].HigherIsBetter {
+		t.Fatal("unexpected first evaluation result", results[0])
+	}
+	if results[1].Name != "auc" || !results[1].HigherIsBetter {
+		t.Fatal("unexpected second evaluation result", results[1])
+	}
+}
+
+func TestEarlyStopping(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+	validDS := createDatasetWithReference(t, testData, ds)
+
+	t.Log("Training booster with early stopping")
+	callbackCalls := 0
+	b, err := lightgbm.Train(ds, []string{
+		"objective=regression",
+		"metric=rmse",
+		"num_leaves=31",
+		"learning_rate=0.3",
+		"verbosity=1",
+	}, lightgbm.TrainOptions{
+		NumRounds:           1000,
+		ValidSets:           []*lightgbm.Dataset{validDS},
+		EarlyStoppingRounds: 5,
+		Callbacks: []lightgbm.TrainCallback{
+			func(env *lightgbm.TrainCallbackEnv) (bool, error) {
+				callbackCalls += 1
+				if env.Iteration != callbackCalls || len(env.EvalResults) != 1 {
+					return false, errors.New("unexpected callback environment")
+				}
+				return false, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Best iteration:", b.BestIteration(), "/ Rounds:", callbackCalls)
+	if b.BestIteration() <= 0 || b.BestIteration() > callbackCalls || callbackCalls >= 1000 {
+		t.Fatal("early stopping did not trigger")
+	}
+	if callbackCalls-b.BestIteration() != 5 {
+		t.Fatal("unexpected number of rounds after the best iteration")
+	}
+	scores := b.BestScores()
+	if len(scores) != 1 || len(scores[0]) != 1 || scores[0][0].Name != "rmse" {
+		t.Fatal("unexpected best scores")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	initLogging(t)
+
+	trainData, _ := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+
+	t.Log("Creating booster from dataset")
+	b, err := lightgbm.Train(ds, []string{
+		"objective=regression",
+		"num_leaves=31",
+		"verbosity=1",
+	}, lightgbm.TrainOptions{
+		NumRounds: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iteration, err := b.CurrentIteration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iteration != 10 {
+		t.Fatal("unexpected current iteration", iteration)
+	}
+
+	t.Log("Rolling back one iteration")
+	err = b.RollbackOneIter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iteration, err = b.CurrentIteration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelsPerIteration, err := b.NumModelPerIteration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	totalModels, err := b.NumberOfTotalModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iteration != 9 || modelsPerIteration != 1 || totalModels != 9 {
+		t.Fatal("unexpected booster state after rollback", iteration, modelsPerIteration, totalModels)
+	}
+}
+
+func TestSaveBestIteration(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	ds := createDataset(t, trainData)
+	validDS := createDatasetWithReference(t, testData, ds)
+
+	t.Log("Training booster with early stopping")
+	b, err := lightgbm.Train(ds, []string{
+		"objective=regression",
+		"metric=rmse",
+		"num_leaves=31",
+		"learning_rate=0.3",
+		"verbosity=1",
+	}, lightgbm.TrainOptions{
+		NumRounds:           1000,
+		ValidSets:           []*lightgbm.Dataset{validDS},
+		EarlyStoppingRounds: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Saving booster up to the best iteration")
+	savedBooster, err := b.ToStringWithOptions(lightgbm.SaveModelOptions{
+		NumIteration: lightgbm.NumIterationBest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Creating new booster from previously saved one")
+	b2, err := lightgbm.NewBoosterFromString(savedBooster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iteration, err := b2.CurrentIteration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iteration != b.BestIteration() {
+		t.Fatal("saved booster does not end at the best iteration")
+	}
+
+	runPrediction(t, b2, testData)
+
+	t.Log("Checking -1 saves every iteration, as in LightGBM")
+	savedBooster, err = b.ToStringWithOptions(lightgbm.SaveModelOptions{
+		NumIteration: -1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b3, err := lightgbm.NewBoosterFromString(savedBooster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iteration, err = b3.CurrentIteration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iteration == b.BestIteration() {
+		t.Fatal("saved booster ends at the best iteration")
+	}
+}
+
+func TestPredictIterationRange(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "regression", 0.3)
+
+	b := trainModel(t, "regression", trainData)
+
+	t.Log("Creating predictors using the first trees only")
+	p, err := b.PredictorWithOptions(lightgbm.PredictTypeLeafIndex, nil, lightgbm.PredictorOptions{
+		NumIteration: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves, err := p.PredictLeafIndex(testData.Features[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaves) != 10 {
+		t.Fatal("unexpected number of leaf indices", len(leaves))
+	}
+
+	t.Log("Checking staged predictions differ")
+	fullPredictor, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagedPredictor, err := b.PredictorWithOptions(lightgbm.PredictTypeNormal, nil, lightgbm.PredictorOptions{
+		StartIteration: 0,
+		NumIteration:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fullPredictions, err := fullPredictor.PredictBatch(testData.Features)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagedPredictions, err := stagedPredictor.PredictBatch(testData.Features)
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := true
+	for idx := range fullPredictions {
+		if fullPredictions[idx] != stagedPredictions[idx] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Fatal("predictions using one iteration match the ones using all of them")
+	}
+}
+
+func TestBatchPrediction(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Creating predictor from booster")
+	p, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Predicting test data in batch")
+	batchPredictions, err := p.PredictBatch(testData.Features)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batchPredictions) != len(testData.Features) {
+		t.Fatal("unexpected number of predictions")
+	}
+
+	for idx, data := range testData.Features {
+		var predictions []float64
+
+		predictions, err = p.Predict(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(predictions[0]-batchPredictions[idx]) > 1e-9 {
+			t.Fatal("batch prediction does not match single row prediction")
+		}
+	}
+}
+
+func TestContribPrediction(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Creating predictors from booster")
+	rawPredictor, err := b.Predictor(lightgbm.PredictTypeRawScore, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contribPredictor, err := b.Predictor(lightgbm.PredictTypeContrib, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Checking contributions add up to the raw score")
+	for _, data := range testData.Features {
+		var rawScore []float64
+		var contrib [][]float64
+
+		rawScore, err = rawPredictor.Predict(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contrib, err = contribPredictor.PredictContrib(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contrib) != 1 || len(contrib[0]) != len(data)+1 {
+			t.Fatal("unexpected number of contributions")
+		}
+
+		sum := 0.0
+		for _, value := range contrib[0] {
+			sum += value
+		}
+		if math.Abs(sum-rawScore[0]) > 1e-6 {
+			t.Fatal("contributions do not add up to the raw score")
+		}
+	}
+}
+
+func TestLeafIndexPrediction(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Creating predictor from booster")
+	p, err := b.Predictor(lightgbm.PredictTypeLeafIndex, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Predicting leaf indices of test data")
+	leaves, err := p.PredictLeafIndexBatch(testData.Features)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, data := range testData.Features {
+		var rowLeaves []int32
+
+		rowLeaves, err = p.PredictLeafIndex(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rowLeaves) == 0 || len(rowLeaves) != len(leaves[idx]) {
+			t.Fatal("unexpected number of leaf indices")
+		}
+		for treeIdx, leaf := range rowLeaves {
+			if leaf < 0 || leaf >= 31 {
+				t.Fatal("leaf index out of range")
+			}
+			if leaf != leaves[idx][treeIdx] {
+				t.Fatal("batch leaf index does not match single row leaf index")
+			}
+		}
+	}
+}
+
+func TestModelFileIO(t *testing.T) {
+	initLogging(t)
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Saving booster to file")
+	filename := filepath.Join(t.TempDir(), "model.txt")
+	err := b.SaveModel(filename, lightgbm.SaveModelOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Creating new booster from file")
+	b2, err := lightgbm.NewBoosterFromFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runPrediction(t, b2, testData)
+
+	t.Log("Writing booster to a buffer")
+	var buf bytes.Buffer
+	err = b.WriteModel(&buf, lightgbm.SaveModelOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Creating new booster from reader")
+	b3, err := lightgbm.NewBoosterFromReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runPrediction(t, b3, testData)
+}
+
+func TestDumpModel(t *testing.T) {
+	initLogging(t)
+
+	trainData, _ := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Dumping booster")
+	m, err := b.DumpModel(lightgbm.SaveModelOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Trees) != 100 || len(m.FeatureNames) != 4 {
+		t.Fatal("unexpected model dump")
+	}
+
+	t.Log("Walking trees")
+	for _, tree := range m.Trees {
+		leaves := 0
+		tree.Walk(func(node lightgbm.Node, _ int) bool {
+			if node.IsLeaf() {
+				leaves += 1
+			}
+			return true
+		})
+		if leaves != tree.NumLeaves {
+			t.Fatal("unexpected number of leaves")
+		}
+	}
+}
+
+func TestFeatureImportance(t *testing.T) {
+	initLogging(t)
+
+	trainData, _ := generateTestData(2000, 4, "regression", 0.3)
+
+	b := trainModel(t, "regression", trainData)
+
+	for _, featureImportance := range []lightgbm.FeatureImportance{lightgbm.FeatureImportanceSplit, lightgbm.FeatureImportanceGain} {
+		t.Log("Getting feature importance of type", featureImportance)
+		scores, err := b.FeatureImportance(featureImportance, lightgbm.NumIterationAll)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(scores) != len(trainData.FeatureNames) {
+			t.Fatal("unexpected number of feature scores")
+		}
+		for idx, score := range scores {
+			t.Log("  ->", score.Name, score.Score)
+			if idx > 0 && score.Score > scores[idx-1].Score {
+				t.Fatal("feature scores are not sorted")
+			}
+		}
+
+		// The first feature has the highest weight in the generated labels
+		if featureImportance == lightgbm.FeatureImportanceGain && scores[0].Name != trainData.FeatureNames[0] {
+			t.Fatal("unexpected most important feature")
+		}
+	}
+}
+
+func TestUnmarshalModelDump(t *testing.T) {
+	data := `{
+		"name": "tree", "version": "v4", "num_class": 1, "num_tree_per_iteration": 1, "max_feature_idx": 1,
+		"objective": "binary sigmoid:1", "feature_names": ["a", "b"],
+		"tree_info": [{
+			"tree_index": 0, "num_leaves": 3, "num_cat": 1, "shrinkage": 1,
+			"tree_structure": {
+				"split_index": 0, "split_feature": 1, "split_gain": 10.5, "threshold": "1||3",
+				"decision_type": "==", "default_left": false, "missing_type": "None",
+				"internal_value": 0, "internal_weight": 0, "internal_count": 100,
+				"left_child": {"leaf_index": 0, "leaf_value": 0.5, "leaf_weight": 10, "leaf_count": 40},
+				"right_child": {
+					"split_index": 1, "split_feature": 0, "split_gain": 2.5, "threshold": 0.25,
+					"decision_type": "<=", "default_left": true, "missing_type": "NaN",
+					"internal_value": 0, "internal_weight": 0, "internal_count": 60,
+					"left_child": {"leaf_index": 1, "leaf_value": -0.5, "leaf_weight": 10, "leaf_count": 30},
+					"right_child": {"leaf_index": 2, "leaf_value": 0.1, "leaf_weight": 10, "leaf_count": 30}
+				}
+			}
+		}]
+	}`
+
+	var m lightgbm.Model
+	err := json.Unmarshal([]byte(data), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Trees) != 1 {
+		t.Fatal("unexpected number of trees")
+	}
+
+	root, ok := m.Trees[0].Root.(*lightgbm.SplitNode)
+	if !ok || len(root.Categories) != 2 || root.Categories[0] != 1 || root.Categories[1] != 3 {
+		t.Fatal("unexpected root node")
+	}
+	if leaf, ok := root.Left.(*lightgbm.LeafNode); !ok || leaf.LeafValue != 0.5 {
+		t.Fatal("unexpected left child")
+	}
+	right, ok := root.Right.(*lightgbm.SplitNode)
+	if !ok || right.Threshold != 0.25 || !right.DefaultLeft || right.MissingType != "NaN" {
+		t.Fatal("unexpected right child")
+	}
+
+	depth := 0
+	m.Trees[0].Walk(func(_ lightgbm.Node, d int) bool {
+		if d > depth {
+			depth = d
+		}
+		return true
+	})
+	if depth != 2 {
+		t.Fatal("unexpected tree depth")
+	}
+}
+
+func TestTextModelParity(t *testing.T) {
+	initLogging(t)
+
+	for _, taskType := range []string{"classification", "regression", "multiclass"} {
+		var b *lightgbm.Booster
+
+		trainData, testData := generateTestData(2000, 4, taskType, 0.3)
+
+		if taskType == "multiclass" {
+			var err error
+
+			// Derive three classes from the first two features
+			for idx, features := range trainData.Features {
+				trainData.Labels[idx] = float64(int(math.Abs(features[0]+features[1])) % 3)
+			}
+			b, err = lightgbm.Train(createDataset(t, trainData), []string{
+				"objective=multiclass",
+				"num_class=3",
+				"num_leaves=15",
+				"verbosity=-1",
+			}, lightgbm.TrainOptions{
+				NumRounds: 20,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			b = trainModel(t, taskType, trainData)
+		}
+
+		t.Log("Parsing the model of the", taskType, "booster")
+		modelStr, err := b.ToString(lightgbm.FeatureImportanceSplit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := textmodel.ParseString(modelStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		nativePredictor, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goPredictor, err := textmodel.NewPredictor(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log("Comparing predictions")
+		for idx, features := range testData.Features {
+			var expected []float64
+			var actual []float64
+
+			// Exercise the missing value handling
+			if idx%10 == 0 {
+				features = append([]float64{}, features...)
+				features[idx%len(features)] = math.NaN()
+			}
+
+			expected, err = nativePredictor.Predict(features)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err = goPredictor.Predict(features)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(actual) != len(expected) {
+				t.Fatal("unexpected number of predictions")
+			}
+			for classIdx := range expected {
+				if math.Abs(actual[classIdx]-expected[classIdx]) > 1e-9 {
+					t.Fatal("prediction mismatch", actual, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratedCodeParity(t *testing.T) {
+	initLogging(t)
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not available")
+	}
+
+	trainData, testData := generateTestData(2000, 4, "classification", 0.3)
+
+	b := trainModel(t, "classification", trainData)
+
+	t.Log("Generating Go code from the model")
+	modelStr, err := b.ToString(lightgbm.FeatureImportanceSplit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := textmodel.ParseString(modelStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var src bytes.Buffer
+	err = textmodel.GenerateGo(&src, m, textmodel.GenerateOptions{
+		PackageName: "main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a program that prints the prediction of each test row
+	var mainSrc strings.Builder
+	mainSrc.WriteString("package main\n\nimport \"fmt\"\n\nvar rows = [][]float64{\n")
+	for _, features := range testData.Features {
+		mainSrc.WriteString("\t{")
+		for idx, value := range features {
+			if idx > 0 {
+				mainSrc.WriteString(", ")
+			}
+			mainSrc.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		mainSrc.WriteString("},\n")
+	}
+	mainSrc.WriteString("}\n\nfunc main() {\n\tfor _, row := range rows {\n\t\tfmt.Println(Predict(row)[0])\n\t}\n}\n")
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module generated\n\ngo 1.21\n"), 0o644)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "model.go"), src.Bytes(), 0o644)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "main.go"), []byte(mainSrc.String()), 0o644)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Running generated code")
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatal(err, string(output))
+	}
+	lines := strings.Fields(string(output))
+	if len(lines) != len(testData.Features) {
+		t.Fatal("unexpected number of predictions")
+	}
+
+	t.Log("Comparing predictions")
+	p, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, features := range testData.Features {
+		var expected []float64
+		var actual float64
+
+		expected, err = p.Predict(features)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err = strconv.ParseFloat(lines[idx], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(actual-expected[0]) > 1e-9 {
+			t.Fatal("prediction mismatch", actual, expected[0])
+		}
+	}
+}
+
 func initLogging(t *testing.T) {
 	lightgbm.LoggerSetCallback(func(msgType string, msg string) {
 		t.Log("["+msgType+"]:", msg)
@@ -150,13 +1666,39 @@ func generateTestData(samplesCount int, featuresCount int, taskType string, test
 	return train, test
 }
 
+func writeCSV(t *testing.T, filename string, data *TestData) {
+	var sb strings.Builder
+
+	sb.WriteString("label," + strings.Join(data.FeatureNames, ",") + "\n")
+	for idx, features := range data.Features {
+		sb.WriteString(strconv.FormatFloat(data.Labels[idx], 'g', -1, 64))
+		for _, value := range features {
+			sb.WriteString("," + strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		sb.WriteString("\n")
+	}
+
+	err := os.WriteFile(filename, []byte(sb.String()), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func trainModel(t *testing.T, taskType string, trainData *TestData) *lightgbm.Booster {
-	var boosterParams []string
-	var b *lightgbm.Booster
+	ds := createDataset(t, trainData)
+
+	return trainBooster(t, taskType, ds)
+}
+
+func createDataset(t *testing.T, trainData *TestData) *lightgbm.Dataset {
+	return createDatasetWithReference(t, trainData, nil)
+}
+
+func createDatasetWithReference(t *testing.T, trainData *TestData, refDS *lightgbm.Dataset) *lightgbm.Dataset {
 	var err error
 
 	t.Log("Creating training dataset")
-	ds := lightgbm.NewDataset(nil)
+	ds := lightgbm.NewDatasetWithReference(nil, refDS)
 
 	t.Log("Adding training data")
 	for _, data := range trainData.Features {
@@ -180,6 +1722,15 @@ func trainModel(t *testing.T, taskType string, trainData *TestData) *lightgbm.Bo
 		}
 	}
 
+	// Done
+	return ds
+}
+
+func trainBooster(t *testing.T, taskType string, ds *lightgbm.Dataset) *lightgbm.Booster {
+	var boosterParams []string
+	var b *lightgbm.Booster
+	var err error
+
 	t.Log("Creating booster from dataset")
 	if taskType == "regression" {
 		boosterParams = []string{
@@ -237,7 +1788,7 @@ func trainModel(t *testing.T, taskType string, trainData *TestData) *lightgbm.Bo
 
 func runPrediction(t *testing.T, b *lightgbm.Booster, testData *TestData) {
 	t.Log("Creating predictor from booster")
-	p, err := b.Predictor(false, nil)
+	p, err := b.Predictor(lightgbm.PredictTypeNormal, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
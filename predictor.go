@@ -10,22 +10,47 @@ import (
 
 // -----------------------------------------------------------------------------
 
+type PredictorOptions struct {
+	StartIteration int
+
+	// NumIteration is the number of iterations to use. Use NumIterationAll to use every iteration or
+	// NumIterationBest to use up to the best iteration found by Train.
+	NumIteration int
+}
+
 type Predictor struct {
-	ptr           unsafe.Pointer
-	b             *Booster
-	featuresCount int
-	classesCount  int
+	ptr            unsafe.Pointer
+	b              *Booster
+	predictType    PredictType
+	startIteration int
+	numIteration   int
+	parameters     string
+	featuresCount  int
+	classesCount   int
+	outputCount    int
 }
 
 // -----------------------------------------------------------------------------
 
-func NewPredictorFromBooster(b *Booster, rawScore bool, parameters []string) (*Predictor, error) {
+func NewPredictorFromBooster(b *Booster, predictType PredictType, parameters []string) (*Predictor, error) {
+	return NewPredictorFromBoosterWithOptions(b, predictType, parameters, PredictorOptions{})
+}
+
+func NewPredictorFromBoosterWithOptions(b *Booster, predictType PredictType, parameters []string, opts PredictorOptions) (*Predictor, error) {
 	var classesCount int
+	var outputCount int
 	var ptr unsafe.Pointer
 
 	if b == nil {
 		return nil, ErrNotInitialized
 	}
+	if opts.StartIteration < 0 {
+		return nil, errors.New("invalid start iteration")
+	}
+
+	params := strings.Join(parameters, " ")
+
+	numIteration := b.resolveNumIteration(opts.NumIteration)
 
 	// Get the number of features in the booster object
 	featuresCount, err := boosterGetFeaturesCount(b.ptr)
@@ -39,18 +64,29 @@ func NewPredictorFromBooster(b *Booster, rawScore bool, parameters []string) (*P
 		return nil, err
 	}
 
+	// Get the number of output values of a single row
+	outputCount, err = boosterCalcNumPredict(b.ptr, 1, int(predictType), opts.StartIteration, numIteration)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the predictor object
-	ptr, err = boosterPredictForMatSingleRowFastInit(b.ptr, rawScore, strings.Join(parameters, " "))
+	ptr, err = boosterPredictForMatSingleRowFastInit(b.ptr, int(predictType), opts.StartIteration, numIteration, params)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create the fast predictor object
 	p := &Predictor{
-		ptr:           ptr,
-		b:             b,
-		featuresCount: featuresCount,
-		classesCount:  classesCount,
+		ptr:            ptr,
+		b:              b,
+		predictType:    predictType,
+		startIteration: opts.StartIteration,
+		numIteration:   numIteration,
+		parameters:     params,
+		featuresCount:  featuresCount,
+		classesCount:   classesCount,
+		outputCount:    outputCount,
 	}
 	runtime.SetFinalizer(p, func(p *Predictor) {
 		p.finalize()
@@ -66,7 +102,7 @@ func (p *Predictor) Predict(features []float64) ([]float64, error) {
 	}
 
 	// Create output
-	out := make([]float64, p.classesCount)
+	out := make([]float64, p.outputCount)
 
 	// Predict
 	err := boosterPredictForMatSingleRowFast(p.ptr, features, out)
@@ -78,7 +114,138 @@ func (p *Predictor) Predict(features []float64) ([]float64, error) {
 	return out, nil
 }
 
+func (p *Predictor) PredictBatch(rows [][]float64) ([]float64, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("empty data")
+	}
+
+	// Flatten rows
+	data := make([]float64, 0, len(rows)*p.featuresCount)
+	for _, row := range rows {
+		if len(row) != p.featuresCount {
+			return nil, errors.New("feature count does not match number of features in model")
+		}
+		data = append(data, row...)
+	}
+
+	// Predict
+	return p.PredictMatrix(data, len(rows))
+}
+
+func (p *Predictor) PredictMatrix(data []float64, rowsCount int) ([]float64, error) {
+	if rowsCount <= 0 {
+		return nil, errors.New("number of rows is not positive")
+	}
+	if len(data) != rowsCount*p.featuresCount {
+		return nil, errors.New("feature count does not match number of features in model")
+	}
+
+	// Create output
+	outLen, err := boosterCalcNumPredict(p.b.ptr, rowsCount, int(p.predictType), p.startIteration, p.numIteration)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, outLen)
+
+	// Predict
+	err = boosterPredictForMat(p.b.ptr, data, rowsCount, int(p.predictType), p.startIteration, p.numIteration, p.parameters, out)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return out, nil
+}
+
+// PredictContrib returns, for each class, the SHAP value of every feature followed by the bias term.
+func (p *Predictor) PredictContrib(features []float64) ([][]float64, error) {
+	if p.predictType != PredictTypeContrib {
+		return nil, errors.New("predictor was not created for feature contributions")
+	}
+
+	out, err := p.Predict(features)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return p.splitContrib(out), nil
+}
+
+func (p *Predictor) PredictContribBatch(rows [][]float64) ([][][]float64, error) {
+	if p.predictType != PredictTypeContrib {
+		return nil, errors.New("predictor was not created for feature contributions")
+	}
+
+	out, err := p.PredictBatch(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Split output by row and class
+	results := make([][][]float64, len(rows))
+	for idx := range results {
+		results[idx] = p.splitContrib(out[idx*p.outputCount : (idx+1)*p.outputCount])
+	}
+
+	// Done
+	return results, nil
+}
+
+// PredictLeafIndex returns the index of the leaf reached in every tree of the model.
+func (p *Predictor) PredictLeafIndex(features []float64) ([]int32, error) {
+	if p.predictType != PredictTypeLeafIndex {
+		return nil, errors.New("predictor was not created for leaf indices")
+	}
+
+	out, err := p.Predict(features)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return toLeafIndices(out), nil
+}
+
+func (p *Predictor) PredictLeafIndexBatch(rows [][]float64) ([][]int32, error) {
+	if p.predictType != PredictTypeLeafIndex {
+		return nil, errors.New("predictor was not created for leaf indices")
+	}
+
+	out, err := p.PredictBatch(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Split output by row
+	results := make([][]int32, len(rows))
+	for idx := range results {
+		results[idx] = toLeafIndices(out[idx*p.outputCount : (idx+1)*p.outputCount])
+	}
+
+	// Done
+	return results, nil
+}
+
+func (p *Predictor) splitContrib(out []float64) [][]float64 {
+	valuesCount := p.featuresCount + 1
+
+	results := make([][]float64, p.classesCount)
+	for idx := range results {
+		results[idx] = out[idx*valuesCount : (idx+1)*valuesCount]
+	}
+	return results
+}
+
 func (p *Predictor) finalize() {
 	predictFastConfigFree(p.ptr)
 	p.b = nil
 }
+
+func toLeafIndices(out []float64) []int32 {
+	results := make([]int32, len(out))
+	for idx, value := range out {
+		results[idx] = int32(value)
+	}
+	return results
+}
@@ -0,0 +1,241 @@
+package textmodel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+type GenerateOptions struct {
+	PackageName string // Defaults to "model"
+	FuncName    string // Defaults to "Predict"
+
+	// RawScore skips the objective transform in the generated function.
+	RawScore bool
+}
+
+type generator struct {
+	m        *Model
+	opts     GenerateOptions
+	buf      bytes.Buffer
+	useMath  bool
+	useCats  bool
+	treeFunc string
+}
+
+// -----------------------------------------------------------------------------
+
+// GenerateGo writes a standalone Go source file with a function that evaluates the model. The trees are
+// unrolled into if/else chains and the generated function has no dependencies besides the standard library.
+func GenerateGo(w io.Writer, m *Model, opts GenerateOptions) error {
+	if m == nil || m.NumTreePerIteration <= 0 {
+		return errors.New("invalid model")
+	}
+	if len(opts.PackageName) == 0 {
+		opts.PackageName = "model"
+	}
+	if len(opts.FuncName) == 0 {
+		opts.FuncName = "Predict"
+	}
+	if !token.IsIdentifier(opts.PackageName) || !token.IsIdentifier(opts.FuncName) {
+		return errors.New("invalid package or function name")
+	}
+
+	g := &generator{
+		m:        m,
+		opts:     opts,
+		treeFunc: strings.ToLower(opts.FuncName[:1]) + opts.FuncName[1:] + "Tree",
+	}
+
+	// Generate the body first so we know which imports and helpers are needed
+	err := g.writePredictFunc()
+	if err != nil {
+		return err
+	}
+	for idx, t := range m.Trees {
+		g.writeTreeFunc(idx, t)
+	}
+	if g.useCats {
+		g.writeCategoriesFunc()
+	}
+
+	// Write the header
+	var src bytes.Buffer
+	src.WriteString("// Code generated by lgbm2go. DO NOT EDIT.\n\n")
+	src.WriteString("package " + opts.PackageName + "\n\n")
+	if g.useMath {
+		src.WriteString("import \"math\"\n\n")
+	}
+	_, _ = g.buf.WriteTo(&src)
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+func (g *generator) writePredictFunc() error {
+	outputCount := g.m.NumTreePerIteration
+
+	g.printf("// %s returns the prediction of the model for the given features. It expects %d features.\n",
+		g.opts.FuncName, g.m.MaxFeatureIdx+1)
+	g.printf("func %s(features []float64) []float64 {\n", g.opts.FuncName)
+	g.printf("out := make([]float64, %d)\n", outputCount)
+	for idx := range g.m.Trees {
+		g.printf("out[%d] += %s%d(features)\n", idx%outputCount, g.treeFunc, idx)
+	}
+	if g.m.AverageOutput && len(g.m.Trees) > 0 {
+		g.printf("for idx := range out {\nout[idx] /= %d\n}\n", len(g.m.Trees)/outputCount)
+	}
+	if !g.opts.RawScore {
+		err := g.writeTransform()
+		if err != nil {
+			return err
+		}
+	}
+	g.printf("return out\n}\n\n")
+	return nil
+}
+
+func (g *generator) writeTransform() error {
+	t, err := getObjectiveTransform(g.m.Objective)
+	if err != nil {
+		return err
+	}
+
+	switch t.kind {
+	case transformSigmoid:
+		g.useMath = true
+		g.printf("for idx := range out {\nout[idx] = 1 / (1 + math.Exp(%s*out[idx]))\n}\n", formatFloat(-t.scale))
+
+	case transformSoftmax:
+		g.useMath = true
+		g.printf("maxValue := out[0]\nfor _, value := range out[1:] {\nif value > maxValue {\nmaxValue = value\n}\n}\n")
+		g.printf("sum := 0.0\nfor idx := range out {\nout[idx] = math.Exp(out[idx] - maxValue)\nsum += out[idx]\n}\n")
+		g.printf("for idx := range out {\nout[idx] /= sum\n}\n")
+
+	case transformExp:
+		g.useMath = true
+		g.printf("for idx := range out {\nout[idx] = math.Exp(out[idx])\n}\n")
+
+	case transformSoftplus:
+		g.useMath = true
+		g.printf("for idx := range out {\nout[idx] = math.Log1p(math.Exp(out[idx]))\n}\n")
+
+	case transformSignedSquare:
+		g.useMath = true
+		g.printf("for idx := range out {\nout[idx] = math.Copysign(out[idx]*out[idx], out[idx])\n}\n")
+	}
+	return nil
+}
+
+func (g *generator) writeTreeFunc(idx int, t *Tree) {
+	g.printf("func %s%d(features []float64) float64 {\n", g.treeFunc, idx)
+	if t.NumLeaves > 1 {
+		g.writeNode(t, 0)
+	} else {
+		g.writeLeaf(t, 0)
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) writeNode(t *Tree, node int) {
+	if node < 0 {
+		g.writeLeaf(t, ^node)
+		return
+	}
+
+	g.printf("if %s {\n", g.leftCondition(t, node))
+	g.writeNode(t, t.LeftChild[node])
+	g.printf("}\n")
+	g.writeNode(t, t.RightChild[node])
+}
+
+// leftCondition returns the expression that is true when the node sends the features to its left child.
+// It mirrors the numerical and categorical decisions of Tree.GetLeaf.
+func (g *generator) leftCondition(t *Tree, node int) string {
+	value := "features[" + strconv.Itoa(t.SplitFeature[node]) + "]"
+
+	if t.IsCategorical(node) {
+		catIdx := int(t.Threshold[node])
+		bitset := t.CatThreshold[t.CatBoundaries[catIdx]:t.CatBoundaries[catIdx+1]]
+		words := make([]string, len(bitset))
+		for idx, bits := range bitset {
+			words[idx] = strconv.FormatUint(uint64(bits), 10)
+		}
+		g.useCats = true
+		return fmt.Sprintf("%sInCategories(%s, []uint32{%s})", g.treeFunc, value, strings.Join(words, ", "))
+	}
+
+	threshold := t.Threshold[node]
+	thresholdStr := formatFloat(threshold)
+	switch t.MissingType(node) {
+	case MissingTypeZero:
+		g.useMath = true
+		zero := fmt.Sprintf("(math.IsNaN(%[1]s) || (%[1]s >= %[2]s && %[1]s <= %[3]s))", value, formatFloat(-zeroThreshold),
+			formatFloat(zeroThreshold))
+		if t.DefaultLeft(node) {
+			return fmt.Sprintf("%s || %s <= %s", zero, value, thresholdStr)
+		}
+		return fmt.Sprintf("!%s && %s <= %s", zero, value, thresholdStr)
+
+	case MissingTypeNaN:
+		if t.DefaultLeft(node) {
+			return fmt.Sprintf("!(%s > %s)", value, thresholdStr)
+		}
+		return fmt.Sprintf("%s <= %s", value, thresholdStr)
+	}
+
+	// Without a missing type, NaN is evaluated as zero
+	if threshold >= 0 {
+		return fmt.Sprintf("!(%s > %s)", value, thresholdStr)
+	}
+	return fmt.Sprintf("%s <= %s", value, thresholdStr)
+}
+
+func (g *generator) writeLeaf(t *Tree, leaf int) {
+	if !t.IsLinear || len(t.LeafFeatures[leaf]) == 0 {
+		value := t.LeafValue[leaf]
+		if t.IsLinear {
+			value = t.LeafConst[leaf]
+		}
+		g.printf("return %s\n", formatFloat(value))
+		return
+	}
+
+	// Linear leaves fall back to the leaf value if any of its features is NaN
+	g.useMath = true
+	nanChecks := make([]string, len(t.LeafFeatures[leaf]))
+	terms := []string{formatFloat(t.LeafConst[leaf])}
+	for idx, feature := range t.LeafFeatures[leaf] {
+		value := "features[" + strconv.Itoa(feature) + "]"
+		nanChecks[idx] = "math.IsNaN(" + value + ")"
+		terms = append(terms, "float64("+formatFloat(t.LeafCoeff[leaf][idx])+"*"+value+")")
+	}
+	g.printf("if %s {\nreturn %s\n}\n", strings.Join(nanChecks, " || "), formatFloat(t.LeafValue[leaf]))
+	g.printf("return %s\n", strings.Join(terms, " + "))
+}
+
+func (g *generator) writeCategoriesFunc() {
+	g.printf("func %sInCategories(value float64, bitset []uint32) bool {\n", g.treeFunc)
+	g.printf("if value != value || value <= -1 || value >= 2147483647 {\nreturn false\n}\n")
+	g.printf("category := int(value)\n")
+	g.printf("return category/32 < len(bitset) && (bitset[category/32]>>(category%%32))&1 != 0\n}\n\n")
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(&g.buf, format, args...)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,141 @@
+package textmodel
+
+import (
+	"sort"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+type MissingType int
+
+const (
+	MissingTypeNone MissingType = iota
+	MissingTypeZero MissingType = iota
+	MissingTypeNaN  MissingType = iota
+)
+
+const (
+	decisionTypeCategoricalMask = 1
+	decisionTypeDefaultLeftMask = 2
+)
+
+// -----------------------------------------------------------------------------
+
+// Model is a LightGBM model as stored in its text format.
+type Model struct {
+	Version             string
+	NumClass            int
+	NumTreePerIteration int
+	LabelIndex          int
+	MaxFeatureIdx       int
+	Objective           Objective
+	AverageOutput       bool
+	FeatureNames        []string
+	FeatureInfos        []FeatureInfo
+	Trees               []*Tree
+	FeatureImportances  []FeatureImportance
+	Parameters          map[string]string
+
+	// PandasCategorical holds the raw JSON value of the pandas_categorical trailer, if any.
+	PandasCategorical string
+}
+
+// Objective is the objective function of the model, i.e. "binary sigmoid:1" is stored with Name set to
+// "binary" and Params containing "sigmoid" => "1". Flags without a value, like "sqrt", have an empty value.
+type Objective struct {
+	Name   string
+	Params map[string]string
+}
+
+type FeatureInfo struct {
+	Unused        bool
+	IsCategorical bool
+	MinValue      float64
+	MaxValue      float64
+	Categories    []int
+}
+
+type FeatureImportance struct {
+	Name  string
+	Value float64
+}
+
+// Tree holds the nodes of a tree in the same flat layout used by LightGBM. Split nodes are indexed from
+// zero to NumLeaves-2. A negative child index c refers to the leaf ^c.
+type Tree struct {
+	Index          int
+	NumLeaves      int
+	NumCat         int
+	SplitFeature   []int
+	SplitGain      []float64
+	Threshold      []float64
+	DecisionType   []uint8
+	LeftChild      []int
+	RightChild     []int
+	LeafValue      []float64
+	LeafWeight     []float64
+	LeafCount      []int
+	InternalValue  []float64
+	InternalWeight []float64
+	InternalCount  []int
+	CatBoundaries  []int
+	CatThreshold   []uint32
+	IsLinear       bool
+	LeafConst      []float64
+	LeafFeatures   [][]int
+	LeafCoeff      [][]float64
+	Shrinkage      float64
+}
+
+// -----------------------------------------------------------------------------
+
+func (o Objective) String() string {
+	if len(o.Params) == 0 {
+		return o.Name
+	}
+
+	keys := make([]string, 0, len(o.Params))
+	for key := range o.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{o.Name}
+	for _, key := range keys {
+		if len(o.Params[key]) > 0 {
+			parts = append(parts, key+":"+o.Params[key])
+		} else {
+			parts = append(parts, key)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (t *Tree) IsCategorical(node int) bool {
+	return t.DecisionType[node]&decisionTypeCategoricalMask != 0
+}
+
+func (t *Tree) DefaultLeft(node int) bool {
+	return t.DecisionType[node]&decisionTypeDefaultLeftMask != 0
+}
+
+func (t *Tree) MissingType(node int) MissingType {
+	return MissingType((t.DecisionType[node] >> 2) & 3)
+}
+
+// Categories returns the categories that go to the left child of a categorical split node.
+func (t *Tree) Categories(node int) []int {
+	var categories []int
+
+	catIdx := int(t.Threshold[node])
+	bitset := t.CatThreshold[t.CatBoundaries[catIdx]:t.CatBoundaries[catIdx+1]]
+	for idx, bits := range bitset {
+		for bit := 0; bit < 32; bit++ {
+			if bits&(1<<bit) != 0 {
+				categories = append(categories, idx*32+bit)
+			}
+		}
+	}
+	return categories
+}
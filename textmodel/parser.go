@@ -0,0 +1,554 @@
+package textmodel
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+type parserSection int
+
+const (
+	parserSectionHeader parserSection = iota
+	parserSectionTrees
+	parserSectionAfterTrees
+	parserSectionFeatureImportances
+	parserSectionParameters
+	parserSectionEnd
+)
+
+// -----------------------------------------------------------------------------
+
+func ParseFile(filename string) (*Model, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Parse(f)
+}
+
+func ParseString(data string) (*Model, error) {
+	return Parse(strings.NewReader(data))
+}
+
+func Parse(r io.Reader) (*Model, error) {
+	var treeFields map[string]string
+
+	m := &Model{
+		Parameters: make(map[string]string),
+	}
+	header := make(map[string]string)
+	section := parserSectionHeader
+
+	headerParsed := false
+	flushHeader := func() error {
+		if headerParsed {
+			return nil
+		}
+		headerParsed = true
+		return m.parseHeader(header)
+	}
+	flushTree := func() error {
+		if treeFields == nil {
+			return nil
+		}
+		t, err := parseTree(treeFields, m.MaxFeatureIdx+1)
+		if err != nil {
+			return fmt.Errorf("tree %d: %w", len(m.Trees), err)
+		}
+		m.Trees = append(m.Trees, t)
+		treeFields = nil
+		return nil
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		eof := err == io.EOF
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "Tree="):
+			// Trees are validated against the header, which is complete when the first tree starts
+			err = flushHeader()
+			if err == nil {
+				err = flushTree()
+			}
+			if err != nil {
+				return nil, err
+			}
+			section = parserSectionTrees
+			treeFields = map[string]string{"Tree": line[5:]}
+
+		case line == "end of trees":
+			err = flushTree()
+			if err != nil {
+				return nil, err
+			}
+			section = parserSectionAfterTrees
+
+		case line == "feature_importances:":
+			section = parserSectionFeatureImportances
+
+		case line == "parameters:":
+			section = parserSectionParameters
+
+		case line == "end of parameters":
+			section = parserSectionEnd
+
+		case strings.HasPrefix(line, "pandas_categorical:"):
+			m.PandasCategorical = strings.TrimSpace(line[19:])
+
+		case len(line) == 0:
+			// Empty lines only separate sections
+
+		default:
+			switch section {
+			case parserSectionHeader:
+				key, value, _ := strings.Cut(line, "=")
+				header[key] = value
+
+			case parserSectionTrees:
+				key, value, ok := strings.Cut(line, "=")
+				if !ok {
+					return nil, fmt.Errorf("invalid tree line: %s", line)
+				}
+				treeFields[key] = value
+
+			case parserSectionFeatureImportances:
+				idx := strings.LastIndexByte(line, '=')
+				if idx < 0 {
+					return nil, fmt.Errorf("invalid feature importance line: %s", line)
+				}
+				var value float64
+				value, err = strconv.ParseFloat(line[idx+1:], 64)
+				if err != nil {
+					return nil, err
+				}
+				m.FeatureImportances = append(m.FeatureImportances, FeatureImportance{
+					Name:  line[:idx],
+					Value: value,
+				})
+
+			case parserSectionParameters:
+				if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+					key, value, _ := strings.Cut(line[1:len(line)-1], ":")
+					m.Parameters[strings.TrimSpace(key)] = strings.TrimSpace(value)
+				}
+			}
+		}
+
+		if eof {
+			break
+		}
+	}
+	err := flushHeader()
+	if err == nil {
+		err = flushTree()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return m, nil
+}
+
+func (m *Model) parseHeader(header map[string]string) error {
+	var err error
+
+	if _, ok := header["tree"]; !ok {
+		return errors.New("not a LightGBM text model")
+	}
+
+	m.Version = header["version"]
+	m.NumClass, err = parseIntField(header, "num_class", 1)
+	if err != nil {
+		return err
+	}
+	m.NumTreePerIteration, err = parseIntField(header, "num_tree_per_iteration", m.NumClass)
+	if err != nil {
+		return err
+	}
+	m.LabelIndex, err = parseIntField(header, "label_index", 0)
+	if err != nil {
+		return err
+	}
+	m.MaxFeatureIdx, err = parseIntField(header, "max_feature_idx", -1)
+	if err != nil {
+		return err
+	}
+	if m.MaxFeatureIdx < 0 {
+		return errors.New("missing max_feature_idx")
+	}
+	if m.NumTreePerIteration <= 0 {
+		return errors.New("invalid num_tree_per_iteration")
+	}
+	if len(m.Trees)%m.NumTreePerIteration != 0 {
+		return errors.New("the number of trees is not a multiple of the number of trees per iteration")
+	}
+	_, m.AverageOutput = header["average_output"]
+
+	m.Objective = parseObjective(header["objective"])
+
+	if names, ok := header["feature_names"]; ok {
+		m.FeatureNames = strings.Fields(names)
+		if len(m.FeatureNames) != m.MaxFeatureIdx+1 {
+			return errors.New("the number of feature names does not match max_feature_idx")
+		}
+	}
+
+	if infos, ok := header["feature_infos"]; ok {
+		for _, info := range strings.Fields(infos) {
+			var fi FeatureInfo
+
+			fi, err = parseFeatureInfo(info)
+			if err != nil {
+				return err
+			}
+			m.FeatureInfos = append(m.FeatureInfos, fi)
+		}
+		if len(m.FeatureInfos) != m.MaxFeatureIdx+1 {
+			return errors.New("the number of feature infos does not match max_feature_idx")
+		}
+	}
+
+	// Done
+	return nil
+}
+
+func parseObjective(s string) Objective {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Objective{}
+	}
+
+	o := Objective{
+		Name: fields[0],
+	}
+	if len(fields) > 1 {
+		o.Params = make(map[string]string)
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, ":")
+			o.Params[key] = value
+		}
+	}
+	return o
+}
+
+func parseFeatureInfo(s string) (FeatureInfo, error) {
+	if s == "none" {
+		return FeatureInfo{Unused: true}, nil
+	}
+
+	// Numerical range?
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		minValue, maxValue, ok := strings.Cut(s[1:len(s)-1], ":")
+		if !ok {
+			return FeatureInfo{}, fmt.Errorf("invalid feature info: %s", s)
+		}
+
+		fi := FeatureInfo{}
+		var err error
+		fi.MinValue, err = strconv.ParseFloat(minValue, 64)
+		if err == nil {
+			fi.MaxValue, err = strconv.ParseFloat(maxValue, 64)
+		}
+		return fi, err
+	}
+
+	// Else it is a list of categories
+	fi := FeatureInfo{
+		IsCategorical: true,
+	}
+	for _, category := range strings.Split(s, ":") {
+		value, err := strconv.Atoi(category)
+		if err != nil {
+			return FeatureInfo{}, fmt.Errorf("invalid feature info: %s", s)
+		}
+		fi.Categories = append(fi.Categories, value)
+	}
+	return fi, nil
+}
+
+// parseTree parses and validates a tree so predicting with it cannot index outside its arrays or the
+// features.
+func parseTree(fields map[string]string, featuresCount int) (*Tree, error) {
+	var err error
+
+	t := &Tree{}
+	t.Index, err = strconv.Atoi(fields["Tree"])
+	if err != nil {
+		return nil, err
+	}
+	t.NumLeaves, err = parseIntField(fields, "num_leaves", -1)
+	if err != nil {
+		return nil, err
+	}
+	if t.NumLeaves <= 0 {
+		return nil, errors.New("missing num_leaves")
+	}
+	t.NumCat, err = parseIntField(fields, "num_cat", 0)
+	if err != nil {
+		return nil, err
+	}
+	if t.NumCat < 0 {
+		return nil, errors.New("invalid num_cat")
+	}
+	t.Shrinkage, err = parseFloatField(fields, "shrinkage", 1)
+	if err != nil {
+		return nil, err
+	}
+	t.IsLinear = fields["is_linear"] == "1"
+
+	// Leaves
+	t.LeafValue, err = parseFloatArray(fields, "leaf_value", t.NumLeaves, true)
+	if err == nil {
+		t.LeafWeight, err = parseFloatArray(fields, "leaf_weight", t.NumLeaves, false)
+	}
+	if err == nil {
+		t.LeafCount, err = parseIntArray(fields, "leaf_count", t.NumLeaves, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Split nodes
+	if t.NumLeaves > 1 {
+		var decisionType []int
+
+		nodesCount := t.NumLeaves - 1
+		t.SplitFeature, err = parseIntArray(fields, "split_feature", nodesCount, true)
+		if err == nil {
+			t.SplitGain, err = parseFloatArray(fields, "split_gain", nodesCount, false)
+		}
+		if err == nil {
+			t.Threshold, err = parseFloatArray(fields, "threshold", nodesCount, true)
+		}
+		if err == nil {
+			decisionType, err = parseIntArray(fields, "decision_type", nodesCount, true)
+		}
+		if err == nil {
+			t.LeftChild, err = parseIntArray(fields, "left_child", nodesCount, true)
+		}
+		if err == nil {
+			t.RightChild, err = parseIntArray(fields, "right_child", nodesCount, true)
+		}
+		if err == nil {
+			t.InternalValue, err = parseFloatArray(fields, "internal_value", nodesCount, false)
+		}
+		if err == nil {
+			t.InternalWeight, err = parseFloatArray(fields, "internal_weight", nodesCount, false)
+		}
+		if err == nil {
+			t.InternalCount, err = parseIntArray(fields, "internal_count", nodesCount, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t.DecisionType = make([]uint8, nodesCount)
+		for idx, value := range decisionType {
+			t.DecisionType[idx] = uint8(value)
+		}
+
+		for node := 0; node < nodesCount; node++ {
+			if !isValidChild(t.LeftChild[node], nodesCount, t.NumLeaves) || !isValidChild(t.RightChild[node], nodesCount, t.NumLeaves) {
+				return nil, errors.New("invalid child index")
+			}
+			if t.SplitFeature[node] < 0 || t.SplitFeature[node] >= featuresCount {
+				return nil, errors.New("invalid split feature")
+			}
+		}
+	}
+
+	// Categorical splits
+	if t.NumCat > 0 {
+		var catThreshold []int64
+
+		t.CatBoundaries, err = parseIntArray(fields, "cat_boundaries", t.NumCat+1, true)
+		if err != nil {
+			return nil, err
+		}
+		for idx, boundary := range t.CatBoundaries {
+			if boundary < 0 || (idx > 0 && boundary < t.CatBoundaries[idx-1]) {
+				return nil, errors.New("invalid cat_boundaries")
+			}
+		}
+		catThreshold, err = parseInt64Array(fields, "cat_threshold", t.CatBoundaries[t.NumCat])
+		if err != nil {
+			return nil, err
+		}
+		t.CatThreshold = make([]uint32, len(catThreshold))
+		for idx, value := range catThreshold {
+			t.CatThreshold[idx] = uint32(value)
+		}
+	}
+	for node := 0; node < t.NumLeaves-1; node++ {
+		if t.IsCategorical(node) {
+			threshold := t.Threshold[node]
+			if threshold < 0 || threshold >= float64(t.NumCat) || threshold != math.Trunc(threshold) {
+				return nil, errors.New("invalid categorical split")
+			}
+		}
+	}
+
+	// Linear leaves
+	if t.IsLinear {
+		var leafFeaturesCount []int
+		var leafFeatures []int
+		var leafCoeff []float64
+
+		t.LeafConst, err = parseFloatArray(fields, "leaf_const", t.NumLeaves, true)
+		if err == nil {
+			leafFeaturesCount, err = parseIntArray(fields, "num_features", t.NumLeaves, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		total := 0
+		for _, count := range leafFeaturesCount {
+			if count < 0 {
+				return nil, errors.New("invalid num_features")
+			}
+			total += count
+		}
+		leafFeatures, err = parseIntArray(fields, "leaf_features", total, total > 0)
+		if err == nil {
+			leafCoeff, err = parseFloatArray(fields, "leaf_coeff", total, total > 0)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, feature := range leafFeatures {
+			if feature < 0 || feature >= featuresCount {
+				return nil, errors.New("invalid leaf feature")
+			}
+		}
+
+		t.LeafFeatures = make([][]int, t.NumLeaves)
+		t.LeafCoeff = make([][]float64, t.NumLeaves)
+		ofs := 0
+		for leaf, count := range leafFeaturesCount {
+			t.LeafFeatures[leaf] = leafFeatures[ofs : ofs+count]
+			t.LeafCoeff[leaf] = leafCoeff[ofs : ofs+count]
+			ofs += count
+		}
+	}
+
+	// Done
+	return t, nil
+}
+
+func isValidChild(child int, nodesCount int, leavesCount int) bool {
+	if child < 0 {
+		return ^child < leavesCount
+	}
+	return child < nodesCount
+}
+
+func parseIntField(fields map[string]string, key string, defValue int) (int, error) {
+	value, ok := fields[key]
+	if !ok {
+		return defValue, nil
+	}
+	result, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return result, nil
+}
+
+func parseFloatField(fields map[string]string, key string, defValue float64) (float64, error) {
+	value, ok := fields[key]
+	if !ok {
+		return defValue, nil
+	}
+	result, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return result, nil
+}
+
+func parseFloatArray(fields map[string]string, key string, count int, required bool) ([]float64, error) {
+	values, ok := fields[key]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("missing %s", key)
+		}
+		return nil, nil
+	}
+
+	items := strings.Fields(values)
+	if len(items) != count {
+		return nil, fmt.Errorf("unexpected number of %s values", key)
+	}
+	results := make([]float64, count)
+	for idx, item := range items {
+		var err error
+
+		results[idx], err = strconv.ParseFloat(item, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", key, err)
+		}
+	}
+	return results, nil
+}
+
+func parseIntArray(fields map[string]string, key string, count int, required bool) ([]int, error) {
+	values, ok := fields[key]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("missing %s", key)
+		}
+		return nil, nil
+	}
+
+	items := strings.Fields(values)
+	if len(items) != count {
+		return nil, fmt.Errorf("unexpected number of %s values", key)
+	}
+	results := make([]int, count)
+	for idx, item := range items {
+		var err error
+
+		results[idx], err = strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", key, err)
+		}
+	}
+	return results, nil
+}
+
+func parseInt64Array(fields map[string]string, key string, count int) ([]int64, error) {
+	items := strings.Fields(fields[key])
+	if len(items) != count {
+		return nil, fmt.Errorf("unexpected number of %s values", key)
+	}
+	results := make([]int64, count)
+	for idx, item := range items {
+		var err error
+
+		results[idx], err = strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", key, err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,232 @@
+package textmodel
+
+import (
+	"errors"
+	"math"
+)
+
+// -----------------------------------------------------------------------------
+
+const (
+	zeroThreshold = 1e-35
+)
+
+// -----------------------------------------------------------------------------
+
+type PredictorOptions struct {
+	StartIteration int
+	NumIteration   int // Zero or negative uses every iteration after StartIteration
+
+	// RawScore disables the objective transform, like PredictTypeRawScore does in the native predictor.
+	RawScore bool
+}
+
+// Predictor evaluates a parsed model without the native library.
+type Predictor struct {
+	m             *Model
+	firstTree     int
+	lastTree      int
+	iterations    int
+	featuresCount int
+	outputCount   int
+	transform     func(raw []float64)
+}
+
+// -----------------------------------------------------------------------------
+
+func NewPredictor(m *Model) (*Predictor, error) {
+	return NewPredictorWithOptions(m, PredictorOptions{})
+}
+
+func NewPredictorWithOptions(m *Model, opts PredictorOptions) (*Predictor, error) {
+	if m == nil || m.NumTreePerIteration <= 0 {
+		return nil, errors.New("invalid model")
+	}
+	if opts.StartIteration < 0 {
+		return nil, errors.New("invalid start iteration")
+	}
+
+	// Calculate the range of trees to evaluate
+	totalIterations := len(m.Trees) / m.NumTreePerIteration
+	startIteration := opts.StartIteration
+	if startIteration > totalIterations {
+		startIteration = totalIterations
+	}
+	iterations := totalIterations - startIteration
+	if opts.NumIteration > 0 && opts.NumIteration < iterations {
+		iterations = opts.NumIteration
+	}
+
+	p := &Predictor{
+		m:             m,
+		firstTree:     startIteration * m.NumTreePerIteration,
+		lastTree:      (startIteration + iterations) * m.NumTreePerIteration,
+		iterations:    iterations,
+		featuresCount: m.MaxFeatureIdx + 1,
+		outputCount:   m.NumTreePerIteration,
+	}
+
+	// Select the objective transform
+	if !opts.RawScore {
+		var err error
+
+		p.transform, err = objectiveTransform(m.Objective)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Done
+	return p, nil
+}
+
+func (p *Predictor) Predict(features []float64) ([]float64, error) {
+	if len(features) != p.featuresCount {
+		return nil, errors.New("feature count does not match number of features in model")
+	}
+
+	// Accumulate the output of each tree in its class
+	out := make([]float64, p.outputCount)
+	for idx := p.firstTree; idx < p.lastTree; idx++ {
+		out[idx%p.outputCount] += p.m.Trees[idx].Predict(features)
+	}
+	if p.m.AverageOutput && p.iterations > 0 {
+		for idx := range out {
+			out[idx] /= float64(p.iterations)
+		}
+	}
+
+	// Apply the objective transform
+	if p.transform != nil {
+		p.transform(out)
+	}
+
+	// Done
+	return out, nil
+}
+
+// Predict returns the raw output of the tree for the given features.
+func (t *Tree) Predict(features []float64) float64 {
+	leaf := 0
+	if t.NumLeaves > 1 {
+		leaf = t.GetLeaf(features)
+	}
+	if !t.IsLinear {
+		return t.LeafValue[leaf]
+	}
+
+	output := t.LeafConst[leaf]
+	for idx, feature := range t.LeafFeatures[leaf] {
+		value := features[feature]
+		if math.IsNaN(value) {
+			return t.LeafValue[leaf]
+		}
+		output += t.LeafCoeff[leaf][idx] * value
+	}
+	return output
+}
+
+// GetLeaf returns the index of the leaf reached by the given features.
+func (t *Tree) GetLeaf(features []float64) int {
+	node := 0
+	for node >= 0 {
+		if t.IsCategorical(node) {
+			node = t.categoricalDecision(features[t.SplitFeature[node]], node)
+		} else {
+			node = t.numericalDecision(features[t.SplitFeature[node]], node)
+		}
+	}
+	return ^node
+}
+
+func (t *Tree) numericalDecision(value float64, node int) int {
+	missingType := t.MissingType(node)
+	if math.IsNaN(value) && missingType != MissingTypeNaN {
+		value = 0
+	}
+	if (missingType == MissingTypeZero && value >= -zeroThreshold && value <= zeroThreshold) ||
+		(missingType == MissingTypeNaN && math.IsNaN(value)) {
+		if t.DefaultLeft(node) {
+			return t.LeftChild[node]
+		}
+		return t.RightChild[node]
+	}
+	if value <= t.Threshold[node] {
+		return t.LeftChild[node]
+	}
+	return t.RightChild[node]
+}
+
+func (t *Tree) categoricalDecision(value float64, node int) int {
+	// NaN, negative and out of range values always go to the right
+	if math.IsNaN(value) || value <= -1 || value >= math.MaxInt32 {
+		return t.RightChild[node]
+	}
+	category := int(value)
+
+	catIdx := int(t.Threshold[node])
+	bitset := t.CatThreshold[t.CatBoundaries[catIdx]:t.CatBoundaries[catIdx+1]]
+	if category/32 < len(bitset) && (bitset[category/32]>>(category%32))&1 != 0 {
+		return t.LeftChild[node]
+	}
+	return t.RightChild[node]
+}
+
+func objectiveTransform(o Objective) (func(raw []float64), error) {
+	t, err := getObjectiveTransform(o)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case transformSigmoid:
+		return func(raw []float64) {
+			for idx := range raw {
+				raw[idx] = 1 / (1 + math.Exp(-t.scale*raw[idx]))
+			}
+		}, nil
+
+	case transformSoftmax:
+		return softmax, nil
+
+	case transformExp:
+		return func(raw []float64) {
+			for idx := range raw {
+				raw[idx] = math.Exp(raw[idx])
+			}
+		}, nil
+
+	case transformSoftplus:
+		return func(raw []float64) {
+			for idx := range raw {
+				raw[idx] = math.Log1p(math.Exp(raw[idx]))
+			}
+		}, nil
+
+	case transformSignedSquare:
+		return func(raw []float64) {
+			for idx := range raw {
+				raw[idx] = math.Copysign(raw[idx]*raw[idx], raw[idx])
+			}
+		}, nil
+	}
+	return nil, nil
+}
+
+func softmax(raw []float64) {
+	maxValue := raw[0]
+	for _, value := range raw[1:] {
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	sum := 0.0
+	for idx := range raw {
+		raw[idx] = math.Exp(raw[idx] - maxValue)
+		sum += raw[idx]
+	}
+	for idx := range raw {
+		raw[idx] /= sum
+	}
+}
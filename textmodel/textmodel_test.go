@@ -0,0 +1,422 @@
+package textmodel_test
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mxmauro/lightgbm/textmodel"
+)
+
+// -----------------------------------------------------------------------------
+
+func TestParse(t *testing.T) {
+	m, err := textmodel.ParseFile(filepath.Join("testdata", "model.txt"))
+	if err != nil {
+		t.Fatalf("unable to parse model [err=%v]", err)
+	}
+
+	if m.Version != "v4" || m.NumClass != 1 || m.NumTreePerIteration != 1 || m.MaxFeatureIdx != 2 {
+		t.Fatalf("unexpected header values")
+	}
+	if m.Objective.Name != "binary" || m.Objective.Params["sigmoid"] != "1" || m.Objective.String() != "binary sigmoid:1" {
+		t.Fatalf("unexpected objective %q", m.Objective.String())
+	}
+	if !reflect.DeepEqual(m.FeatureNames, []string{"f0", "f1", "f2"}) {
+		t.Fatalf("unexpected feature names %v", m.FeatureNames)
+	}
+	if len(m.FeatureInfos) != 3 || m.FeatureInfos[0].MaxValue != 5 || !m.FeatureInfos[1].Unused ||
+		!m.FeatureInfos[2].IsCategorical || !reflect.DeepEqual(m.FeatureInfos[2].Categories, []int{1, 3, 7}) {
+		t.Fatalf("unexpected feature infos %+v", m.FeatureInfos)
+	}
+	if len(m.Trees) != 3 {
+		t.Fatalf("unexpected number of trees %d", len(m.Trees))
+	}
+
+	// Numerical and categorical splits
+	tree := m.Trees[0]
+	if tree.IsCategorical(0) || !tree.DefaultLeft(0) || tree.MissingType(0) != textmodel.MissingTypeNaN {
+		t.Fatalf("unexpected decision type of the numerical split")
+	}
+	if !tree.IsCategorical(1) || !reflect.DeepEqual(tree.Categories(1), []int{1, 3}) {
+		t.Fatalf("unexpected categorical split")
+	}
+
+	// Linear tree
+	tree = m.Trees[1]
+	if !tree.IsLinear || tree.Shrinkage != 0.1 ||
+		!reflect.DeepEqual(tree.LeafFeatures, [][]int{{0}, {0, 1}}) ||
+		!reflect.DeepEqual(tree.LeafCoeff, [][]float64{{0.1}, {0.2, 0.3}}) {
+		t.Fatalf("unexpected linear tree %+v", tree)
+	}
+
+	// Single leaf tree
+	tree = m.Trees[2]
+	if tree.NumLeaves != 1 || len(tree.SplitFeature) != 0 || tree.LeafValue[0] != -0.05 {
+		t.Fatalf("unexpected single leaf tree %+v", tree)
+	}
+
+	if len(m.FeatureImportances) != 3 || m.FeatureImportances[2].Name != "f2" {
+		t.Fatalf("unexpected feature importances %v", m.FeatureImportances)
+	}
+	if m.Parameters["objective"] != "binary" || m.Parameters["num_leaves"] != "31" {
+		t.Fatalf("unexpected parameters %v", m.Parameters)
+	}
+	if m.PandasCategorical != "null" {
+		t.Fatalf("unexpected pandas categorical %q", m.PandasCategorical)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := textmodel.ParseString("version=v4\n")
+	if err == nil {
+		t.Fatalf("a model without the tree header was accepted")
+	}
+
+	_, err = textmodel.ParseString(strings.Join([]string{
+		"tree",
+		"max_feature_idx=0",
+		"Tree=0",
+		"num_leaves=2",
+		"split_feature=0",
+		"threshold=1",
+		"decision_type=0",
+		"left_child=-1",
+		"right_child=-3",
+		"leaf_value=1 2",
+	}, "\n"))
+	if err == nil {
+		t.Fatalf("a tree with an invalid child index was accepted")
+	}
+
+	_, err = textmodel.ParseString(invalidTreeModel(nil))
+	if err != nil {
+		t.Fatalf("unable to parse the base model of the invalid trees [err=%v]", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		fields map[string]string
+	}{
+		{"split feature out of range", map[string]string{"split_feature": "2"}},
+		{"negative split feature", map[string]string{"split_feature": "-1"}},
+		{"negative num_cat", map[string]string{"num_cat": "-1"}},
+		{"categorical split without categories", map[string]string{"decision_type": "1"}},
+		{"categorical split out of range", map[string]string{
+			"num_cat": "1", "decision_type": "1", "threshold": "1", "cat_boundaries": "0 1", "cat_threshold": "1",
+		}},
+		{"negative cat_boundaries", map[string]string{
+			"num_cat": "1", "decision_type": "1", "threshold": "0", "cat_boundaries": "-1 0", "cat_threshold": "",
+		}},
+		{"decreasing cat_boundaries", map[string]string{
+			"num_cat": "2", "decision_type": "1", "threshold": "0", "cat_boundaries": "0 2 1", "cat_threshold": "1",
+		}},
+		{"negative num_features", map[string]string{
+			"is_linear": "1", "leaf_const": "0 0", "num_features": "-1 1", "leaf_features": "", "leaf_coeff": "",
+		}},
+		{"leaf feature out of range", map[string]string{
+			"is_linear": "1", "leaf_const": "0 0", "num_features": "1 0", "leaf_features": "2", "leaf_coeff": "0.5",
+		}},
+	} {
+		_, err = textmodel.ParseString(invalidTreeModel(tc.fields))
+		if err == nil {
+			t.Fatalf("a tree with %s was accepted", tc.name)
+		}
+	}
+}
+
+func TestPredict(t *testing.T) {
+	m, err := textmodel.ParseFile(filepath.Join("testdata", "model.txt"))
+	if err != nil {
+		t.Fatalf("unable to parse model [err=%v]", err)
+	}
+	p, err := textmodel.NewPredictorWithOptions(m, textmodel.PredictorOptions{
+		RawScore: true,
+	})
+	if err != nil {
+		t.Fatalf("unable to create predictor [err=%v]", err)
+	}
+
+	testCases := []struct {
+		features []float64
+		expected float64
+	}{
+		{[]float64{1, 0, 3}, 0.65},          // Left numerical branch, category in set, linear leaf
+		{[]float64{math.NaN(), 2, 2}, 0.24}, // NaN goes to the default left branch, linear leaf with NaN feature
+		{[]float64{4, math.NaN(), 1}, 0.65}, // NaN treated as zero when missing type is None
+		{[]float64{0, 1, -0.5}, 0.05},       // Fractional negative category truncates to zero
+		{[]float64{0, 1, math.NaN()}, 0.05}, // NaN category goes to the right
+		{[]float64{-2, 0.5, 1 << 40}, 0.55}, // Out of range category goes to the right
+	}
+	for idx, tc := range testCases {
+		var out []float64
+
+		out, err = p.Predict(tc.features)
+		if err != nil {
+			t.Fatalf("unable to predict [err=%v]", err)
+		}
+		if len(out) != 1 || math.Abs(out[0]-tc.expected) > 1e-12 {
+			t.Fatalf("unexpected raw prediction of case #%d: %v (expected %v)", idx, out, tc.expected)
+		}
+	}
+
+	// Check the sigmoid transform and the iteration range
+	p, err = textmodel.NewPredictorWithOptions(m, textmodel.PredictorOptions{
+		StartIteration: 1,
+		NumIteration:   1,
+	})
+	if err != nil {
+		t.Fatalf("unable to create predictor [err=%v]", err)
+	}
+	out, err := p.Predict([]float64{1, 0, 3})
+	if err != nil {
+		t.Fatalf("unable to predict [err=%v]", err)
+	}
+	if math.Abs(out[0]-1/(1+math.Exp(-0.6))) > 1e-12 {
+		t.Fatalf("unexpected prediction %v", out)
+	}
+
+	_, err = p.Predict([]float64{1, 2})
+	if err == nil {
+		t.Fatalf("a row with a wrong number of features was accepted")
+	}
+}
+
+func TestObjectiveTransforms(t *testing.T) {
+	testCases := []struct {
+		objective string
+		raw       []float64
+		expected  []float64
+	}{
+		{"binary sigmoid:2", []float64{0.5}, []float64{1 / (1 + math.Exp(-1))}},
+		{"multiclass num_class:3", []float64{1, 2, 3}, softmax([]float64{1, 2, 3})},
+		{"multiclassova num_class:2 sigmoid:1", []float64{0, 1}, []float64{0.5, 1 / (1 + math.Exp(-1))}},
+		{"cross_entropy", []float64{-1}, []float64{1 / (1 + math.Exp(1))}},
+		{"cross_entropy_lambda", []float64{1}, []float64{math.Log1p(math.E)}},
+		{"poisson", []float64{1}, []float64{math.E}},
+		{"tweedie", []float64{0}, []float64{1}},
+		{"regression sqrt", []float64{-3}, []float64{-9}},
+		{"regression", []float64{-3}, []float64{-3}},
+		{"lambdarank", []float64{2}, []float64{2}},
+	}
+	for _, tc := range testCases {
+		m := singleLeafModel(t, tc.objective, tc.raw)
+
+		p, err := textmodel.NewPredictor(m)
+		if err != nil {
+			t.Fatalf("unable to create predictor [err=%v]", err)
+		}
+		out, err := p.Predict([]float64{0})
+		if err != nil {
+			t.Fatalf("unable to predict [err=%v]", err)
+		}
+		generated := runGeneratedModel(t, m, [][]float64{{0}})[0]
+		if len(out) != len(tc.expected) || len(generated) != len(tc.expected) {
+			t.Fatalf("unexpected number of %q outputs", tc.objective)
+		}
+		for idx := range tc.expected {
+			if math.Abs(out[idx]-tc.expected[idx]) > 1e-12 {
+				t.Fatalf("unexpected %q prediction %v (expected %v)", tc.objective, out, tc.expected)
+			}
+			if math.Abs(generated[idx]-tc.expected[idx]) > 1e-12 {
+				t.Fatalf("unexpected %q generated code prediction %v (expected %v)", tc.objective, generated, tc.expected)
+			}
+		}
+	}
+}
+
+func TestAverageOutput(t *testing.T) {
+	m := singleLeafModel(t, "regression", []float64{1, 3})
+	m.NumClass = 1
+	m.NumTreePerIteration = 1
+	m.AverageOutput = true
+
+	p, err := textmodel.NewPredictor(m)
+	if err != nil {
+		t.Fatalf("unable to create predictor [err=%v]", err)
+	}
+	out, err := p.Predict([]float64{0})
+	if err != nil {
+		t.Fatalf("unable to predict [err=%v]", err)
+	}
+	if out[0] != 2 {
+		t.Fatalf("unexpected averaged prediction %v", out)
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	m, err := textmodel.ParseFile(filepath.Join("testdata", "model.txt"))
+	if err != nil {
+		t.Fatalf("unable to parse model [err=%v]", err)
+	}
+	p, err := textmodel.NewPredictor(m)
+	if err != nil {
+		t.Fatalf("unable to create predictor [err=%v]", err)
+	}
+
+	rows := [][]float64{
+		{1, 0, 3},
+		{math.NaN(), 2, 2},
+		{4, math.NaN(), 1},
+		{0, 1, -0.5},
+		{-2, 0.5, 1 << 40},
+	}
+	results := runGeneratedModel(t, m, rows)
+	for idx, row := range rows {
+		var expected []float64
+
+		expected, err = p.Predict(row)
+		if err != nil {
+			t.Fatalf("unable to predict [err=%v]", err)
+		}
+		if len(results[idx]) != len(expected) || math.Abs(results[idx][0]-expected[0]) > 1e-12 {
+			t.Fatalf("generated code prediction mismatch for row #%d: %v (expected %v)", idx, results[idx], expected)
+		}
+	}
+}
+
+// runGeneratedModel compiles the model into Go code and returns the outputs of the generated function
+// for each row.
+func runGeneratedModel(t *testing.T, m *textmodel.Model, rows [][]float64) [][]float64 {
+	var src bytes.Buffer
+	var mainSrc strings.Builder
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not available")
+	}
+
+	err = textmodel.GenerateGo(&src, m, textmodel.GenerateOptions{
+		PackageName: "main",
+	})
+	if err != nil {
+		t.Fatalf("unable to generate code [err=%v]", err)
+	}
+
+	// Create a program that prints the prediction of each row
+	mainSrc.WriteString("package main\n\nimport (\n\t\"fmt\"\n\t\"math\"\n)\n\n")
+	mainSrc.WriteString("var _ = math.NaN\n\nvar rows = [][]float64{\n")
+	for _, row := range rows {
+		mainSrc.WriteString("\t{")
+		for idx, value := range row {
+			if idx > 0 {
+				mainSrc.WriteString(", ")
+			}
+			if math.IsNaN(value) {
+				mainSrc.WriteString("math.NaN()")
+			} else {
+				mainSrc.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+			}
+		}
+		mainSrc.WriteString("},\n")
+	}
+	mainSrc.WriteString("}\n\nfunc main() {\n\tfor _, row := range rows {\n\t\tfmt.Println(Predict(row))\n\t}\n}\n")
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module generated\n\ngo 1.21\n",
+		"model.go": src.String(),
+		"main.go":  mainSrc.String(),
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("unable to write %s [err=%v]", name, err)
+		}
+	}
+
+	// Run it and parse the output
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("unable to run generated code [err=%v]\n%s", err, output)
+	}
+
+	results := make([][]float64, 0, len(rows))
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var values []float64
+
+		for _, field := range strings.Fields(strings.Trim(line, "[]")) {
+			var value float64
+
+			value, err = strconv.ParseFloat(field, 64)
+			if err != nil {
+				t.Fatalf("unexpected output %q", line)
+			}
+			values = append(values, value)
+		}
+		results = append(results, values)
+	}
+	if len(results) != len(rows) {
+		t.Fatalf("unexpected number of output lines")
+	}
+	return results
+}
+
+// singleLeafModel creates a model with one single leaf tree per raw value.
+func singleLeafModel(t *testing.T, objective string, raw []float64) *textmodel.Model {
+	var sb strings.Builder
+
+	sb.WriteString("tree\nversion=v4\nmax_feature_idx=0\n")
+	sb.WriteString("num_class=" + strconv.Itoa(len(raw)) + "\n")
+	sb.WriteString("num_tree_per_iteration=" + strconv.Itoa(len(raw)) + "\n")
+	sb.WriteString("objective=" + objective + "\n")
+	for idx, value := range raw {
+		sb.WriteString("Tree=" + strconv.Itoa(idx) + "\nnum_leaves=1\n")
+		sb.WriteString("leaf_value=" + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+	}
+	sb.WriteString("end of trees\n")
+
+	m, err := textmodel.ParseString(sb.String())
+	if err != nil {
+		t.Fatalf("unable to parse model [err=%v]", err)
+	}
+	return m
+}
+
+func softmax(values []float64) []float64 {
+	sum := 0.0
+	out := make([]float64, len(values))
+	for idx, value := range values {
+		out[idx] = math.Exp(value)
+		sum += out[idx]
+	}
+	for idx := range out {
+		out[idx] /= sum
+	}
+	return out
+}
+
+// invalidTreeModel returns a model with two features and a single tree whose fields are replaced or extended
+// with the given ones.
+func invalidTreeModel(fields map[string]string) string {
+	lines := []string{"tree", "max_feature_idx=1", "Tree=0"}
+	keys := []string{"num_leaves", "num_cat", "split_feature", "threshold", "decision_type", "left_child", "right_child",
+		"leaf_value", "cat_boundaries", "cat_threshold", "is_linear", "leaf_const", "num_features", "leaf_features",
+		"leaf_coeff"}
+	values := map[string]string{
+		"num_leaves":    "2",
+		"num_cat":       "0",
+		"split_feature": "0",
+		"threshold":     "1",
+		"decision_type": "0",
+		"left_child":    "-1",
+		"right_child":   "-2",
+		"leaf_value":    "1 2",
+	}
+	for key, value := range fields {
+		values[key] = value
+	}
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			lines = append(lines, key+"="+value)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,98 @@
+package textmodel
+
+import (
+	"errors"
+	"strconv"
+)
+
+// -----------------------------------------------------------------------------
+
+type transformKind int
+
+const (
+	transformNone         transformKind = iota
+	transformSigmoid                    // 1 / (1 + exp(-scale * x))
+	transformSoftmax                    // Applied across the outputs of all classes
+	transformExp                        // exp(x)
+	transformSoftplus                   // log(1 + exp(x))
+	transformSignedSquare               // sign(x) * x^2
+)
+
+// objectiveTransformInfo describes the transform LightGBM applies to the raw score of an objective.
+type objectiveTransformInfo struct {
+	kind transformKind
+
+	// scaleParam is the objective parameter holding the scale of the transform, if any.
+	scaleParam string
+
+	// flag is the objective flag required to apply the transform, if any.
+	flag string
+}
+
+type transform struct {
+	kind  transformKind
+	scale float64
+}
+
+// -----------------------------------------------------------------------------
+
+// objectiveTransforms is shared by Predictor and GenerateGo so both apply the same transforms. Other
+// objectives, like ranking ones or custom objectives, output the raw score.
+var objectiveTransforms = map[string]objectiveTransformInfo{
+	"binary":               {kind: transformSigmoid, scaleParam: "sigmoid"},
+	"multiclassova":        {kind: transformSigmoid, scaleParam: "sigmoid"},
+	"multiclass":           {kind: transformSoftmax},
+	"cross_entropy":        {kind: transformSigmoid},
+	"cross_entropy_lambda": {kind: transformSoftplus},
+	"poisson":              {kind: transformExp},
+	"gamma":                {kind: transformExp},
+	"tweedie":              {kind: transformExp},
+	"regression":           {kind: transformSignedSquare, flag: "sqrt"},
+	"regression_l1":        {kind: transformSignedSquare, flag: "sqrt"},
+	"huber":                {kind: transformSignedSquare, flag: "sqrt"},
+	"fair":                 {kind: transformSignedSquare, flag: "sqrt"},
+	"quantile":             {kind: transformSignedSquare, flag: "sqrt"},
+	"mape":                 {kind: transformSignedSquare, flag: "sqrt"},
+}
+
+// -----------------------------------------------------------------------------
+
+func getObjectiveTransform(o Objective) (transform, error) {
+	info, ok := objectiveTransforms[o.Name]
+	if !ok {
+		return transform{kind: transformNone}, nil
+	}
+	if len(info.flag) > 0 {
+		if _, ok = o.Params[info.flag]; !ok {
+			return transform{kind: transformNone}, nil
+		}
+	}
+
+	t := transform{
+		kind:  info.kind,
+		scale: 1,
+	}
+	if len(info.scaleParam) > 0 {
+		var err error
+
+		t.scale, err = o.floatParam(info.scaleParam, 1)
+		if err != nil {
+			return transform{}, err
+		}
+	}
+
+	// Done
+	return t, nil
+}
+
+func (o Objective) floatParam(key string, defValue float64) (float64, error) {
+	value, ok := o.Params[key]
+	if !ok {
+		return defValue, nil
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New("invalid objective parameter " + key)
+	}
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package lightgbm
+
+import (
+	"errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// TrainCallback is invoked after every boosting round. Returning true stops the training.
+type TrainCallback func(env *TrainCallbackEnv) (bool, error)
+
+type TrainCallbackEnv struct {
+	Booster   *Booster
+	Iteration int // Number of completed boosting rounds
+
+	// EvalResults contains the evaluation results of each validation dataset, in the same order they were
+	// passed in TrainOptions.ValidSets.
+	EvalResults [][]EvalResult
+}
+
+type TrainOptions struct {
+	NumRounds int
+	ValidSets []*Dataset
+
+	// EarlyStoppingRounds stops the training if any metric of any validation dataset does not improve in
+	// the given number of rounds. Zero disables early stopping.
+	EarlyStoppingRounds int
+
+	Objective     Objective
+	CustomMetrics []CustomMetric
+	Callbacks     []TrainCallback
+}
+
+// -----------------------------------------------------------------------------
+
+type earlyStoppingState struct {
+	bestValue     []float64
+	bestIteration []int
+}
+
+// -----------------------------------------------------------------------------
+
+func Train(ds *Dataset, parameters []string, opts TrainOptions) (*Booster, error) {
+	if opts.NumRounds <= 0 {
+		return nil, errors.New("number of rounds is not positive")
+	}
+	if opts.EarlyStoppingRounds > 0 && len(opts.ValidSets) == 0 {
+		return nil, errors.New("early stopping requires at least one validation dataset")
+	}
+
+	// Create the booster
+	b, err := NewBoosterFromDataset(ds, parameters, opts.ValidSets)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range opts.CustomMetrics {
+		err = b.AddCustomMetric(m.Name, m.HigherIsBetter, m.Eval)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Prepare the early stopping state of each validation dataset
+	var states []earlyStoppingState
+	var history [][][]EvalResult
+	bestIteration := 0
+	if opts.EarlyStoppingRounds > 0 {
+		states = make([]earlyStoppingState, len(opts.ValidSets))
+	}
+
+	for iteration := 1; iteration <= opts.NumRounds; iteration++ {
+		var isFinished bool
+
+		// Update one iteration
+		if opts.Objective != nil {
+			isFinished, err = b.UpdateOneIterWithObjective(opts.Objective)
+		} else {
+			isFinished, err = b.UpdateOneIter()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isFinished {
+			break
+		}
+
+		// Evaluate validation datasets
+		env := TrainCallbackEnv{
+			Booster:     b,
+			Iteration:   iteration,
+			EvalResults: make([][]EvalResult, len(opts.ValidSets)),
+		}
+		if len(opts.Callbacks) > 0 || states != nil {
+			for idx := range opts.ValidSets {
+				env.EvalResults[idx], err = b.GetEvalResults(FirstValidationDataIndex + idx)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// Invoke callbacks
+		stop := false
+		for _, cb := range opts.Callbacks {
+			var cbStop bool
+
+			cbStop, err = cb(&env)
+			if err != nil {
+				return nil, err
+			}
+			stop = stop || cbStop
+		}
+
+		// Check for early stopping
+		if states != nil {
+			var shouldStop bool
+
+			history = append(history, env.EvalResults)
+			bestIteration, shouldStop = updateEarlyStopping(states, env.EvalResults, iteration, opts.EarlyStoppingRounds)
+			stop = stop || shouldStop
+		}
+		if stop {
+			break
+		}
+	}
+
+	// Record the best iteration
+	if bestIteration > 0 {
+		b.bestIteration = bestIteration
+		b.bestScores = history[bestIteration-1]
+	}
+
+	// Done
+	return b, nil
+}
+
+// updateEarlyStopping records the best value of every metric and returns the best iteration of the
+// metric that triggered the stop or, if none did, of the first metric of the first validation dataset.
+func updateEarlyStopping(states []earlyStoppingState, evalResults [][]EvalResult, iteration int, rounds int) (int, bool) {
+	firstBestIteration := 0
+
+	for idx := range states {
+		state := &states[idx]
+
+		if state.bestValue == nil {
+			state.bestValue = make([]float64, len(evalResults[idx]))
+			state.bestIteration = make([]int, len(evalResults[idx]))
+		}
+
+		for metricIdx, result := range evalResults[idx] {
+			improved := state.bestIteration[metricIdx] == 0
+			if !improved {
+				if result.HigherIsBetter {
+					improved = result.Value > state.bestValue[metricIdx]
+				} else {
+					improved = result.Value < state.bestValue[metricIdx]
+				}
+			}
+			if improved {
+				state.bestValue[metricIdx] = result.Value
+				state.bestIteration[metricIdx] = iteration
+			}
+
+			if iteration-state.bestIteration[metricIdx] >= rounds {
+				return state.bestIteration[metricIdx], true
+			}
+			if idx == 0 && metricIdx == 0 {
+				firstBestIteration = state.bestIteration[metricIdx]
+			}
+		}
+	}
+
+	// Done
+	return firstBestIteration, false
+}
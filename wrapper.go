@@ -119,6 +119,174 @@ typedef int (*lpfnLGBM_BoosterPredictForMatSingleRowFast)(FastConfigHandle fastC
 
 typedef int (*lpfnLGBM_FastConfigFree)(FastConfigHandle fastConfig);
 
+typedef int (*lpfnLGBM_BoosterCalcNumPredict)(BoosterHandle handle,
+                                              int num_row,
+                                              int predict_type,
+                                              int start_iteration,
+                                              int num_iteration,
+                                              int64_t* out_len);
+
+typedef int (*lpfnLGBM_BoosterPredictForMat)(BoosterHandle handle,
+                                             const void* data,
+                                             int data_type,
+                                             int32_t nrow,
+                                             int32_t ncol,
+                                             int is_row_major,
+                                             int predict_type,
+                                             int start_iteration,
+                                             int num_iteration,
+                                             const char* parameter,
+                                             int64_t* out_len,
+                                             double* out_result);
+
+typedef int (*lpfnLGBM_DatasetCreateFromCSR)(const void* indptr,
+                                             int indptr_type,
+                                             const int32_t* indices,
+                                             const void* data,
+                                             int data_type,
+                                             int64_t nindptr,
+                                             int64_t nelem,
+                                             int64_t num_col,
+                                             const char* parameters,
+                                             const DatasetHandle reference,
+                                             DatasetHandle* out);
+
+typedef int (*lpfnLGBM_DatasetCreateFromCSC)(const void* col_ptr,
+                                             int col_ptr_type,
+                                             const int32_t* indices,
+                                             const void* data,
+                                             int data_type,
+                                             int64_t ncol_ptr,
+                                             int64_t nelem,
+                                             int64_t num_row,
+                                             const char* parameters,
+                                             const DatasetHandle reference,
+                                             DatasetHandle* out);
+
+typedef int (*lpfnLGBM_DatasetCreateFromFile)(const char* filename,
+                                              const char* parameters,
+                                              const DatasetHandle reference,
+                                              DatasetHandle* out);
+
+typedef int (*lpfnLGBM_DatasetSaveBinary)(DatasetHandle handle,
+                                          const char* filename);
+
+typedef int (*lpfnLGBM_BoosterUpdateOneIterCustom)(BoosterHandle handle,
+                                                   const float* grad,
+                                                   const float* hess,
+                                                   int* is_finished);
+
+typedef int (*lpfnLGBM_BoosterGetPredict)(BoosterHandle handle,
+                                          int data_idx,
+                                          int64_t* out_len,
+                                          double* out_result);
+
+typedef int (*lpfnLGBM_DatasetGetField)(DatasetHandle handle,
+                                        const char* field_name,
+                                        int* out_len,
+                                        const void** out_ptr,
+                                        int* out_type);
+
+typedef int (*lpfnLGBM_BoosterGetEvalNames)(BoosterHandle handle,
+                                            const int len,
+                                            int* out_len,
+                                            const size_t buffer_len,
+                                            size_t* out_buffer_len,
+                                            char** out_strs);
+
+typedef int (*lpfnLGBM_BoosterGetCurrentIteration)(BoosterHandle handle,
+                                                   int* out_iteration);
+
+typedef int (*lpfnLGBM_BoosterRollbackOneIter)(BoosterHandle handle);
+
+typedef int (*lpfnLGBM_BoosterNumberOfTotalModel)(BoosterHandle handle,
+                                                  int* out_models);
+
+typedef int (*lpfnLGBM_BoosterNumModelPerIteration)(BoosterHandle handle,
+                                                    int* out_tree_per_iteration);
+
+typedef int (*lpfnLGBM_BoosterCreateFromModelfile)(const char* filename,
+                                                   int* out_num_iterations,
+                                                   BoosterHandle* out);
+
+typedef int (*lpfnLGBM_BoosterSaveModel)(BoosterHandle handle,
+                                         int start_iteration,
+                                         int num_iteration,
+                                         int feature_importance_type,
+                                         const char* filename);
+
+typedef int (*lpfnLGBM_BoosterDumpModel)(BoosterHandle handle,
+                                         int start_iteration,
+                                         int num_iteration,
+                                         int feature_importance_type,
+                                         int64_t buffer_len,
+                                         int64_t* out_len,
+                                         char* out_str);
+
+typedef int (*lpfnLGBM_BoosterFeatureImportance)(BoosterHandle handle,
+                                                 int num_iteration,
+                                                 int importance_type,
+                                                 double* out_results);
+
+typedef int (*lpfnLGBM_BoosterGetFeatureNames)(BoosterHandle handle,
+                                               const int len,
+                                               int* out_len,
+                                               const size_t buffer_len,
+                                               size_t* out_buffer_len,
+                                               char** out_strs);
+
+typedef int (*lpfnLGBM_DatasetCreateByReference)(const DatasetHandle reference,
+                                                 int64_t num_total_row,
+                                                 DatasetHandle* out);
+
+typedef int (*lpfnLGBM_DatasetInitStreaming)(DatasetHandle dataset,
+                                             int32_t has_weights,
+                                             int32_t has_init_scores,
+                                             int32_t has_queries,
+                                             int32_t nclasses,
+                                             int32_t nthreads,
+                                             int32_t omp_max_threads);
+
+typedef int (*lpfnLGBM_DatasetPushRowsWithMetadata)(DatasetHandle dataset,
+                                                    const void* data,
+                                                    int data_type,
+                                                    int32_t nrow,
+                                                    int32_t ncol,
+                                                    int32_t start_row,
+                                                    const float* label,
+                                                    const float* weight,
+                                                    const double* init_score,
+                                                    const int32_t* query,
+                                                    int32_t tid);
+
+typedef int (*lpfnLGBM_DatasetMarkFinished)(DatasetHandle dataset);
+
+typedef int (*lpfnLGBM_DatasetSetWaitForManualFinish)(DatasetHandle dataset,
+                                                      int wait);
+
+typedef int (*lpfnLGBM_DatasetGetNumData)(DatasetHandle handle,
+                                          int* out);
+
+typedef int (*lpfnLGBM_DatasetGetNumFeature)(DatasetHandle handle,
+                                             int* out);
+
+typedef int (*lpfnLGBM_DatasetGetFeatureNumBin)(DatasetHandle handle,
+                                                int feature,
+                                                int* out);
+
+typedef int (*lpfnLGBM_DatasetGetFeatureNames)(DatasetHandle handle,
+                                               const int len,
+                                               int* num_feature_names,
+                                               const size_t buffer_len,
+                                               size_t* out_buffer_len,
+                                               char** feature_names);
+
+typedef int (*lpfnLGBM_DatasetGetSubset)(const DatasetHandle handle,
+                                         const int32_t* used_row_indices,
+                                         int32_t num_used_row_indices,
+                                         const char* parameters,
+                                         DatasetHandle* out);
+
 // -----------------------------------------------------------------------------
 
 static lpfnLGBM_GetLastError fnLGBM_GetLastError = nullptr;
@@ -145,6 +313,49 @@ static lpfnLGBM_BoosterPredictForMatSingleRowFastInit fnLGBM_BoosterPredictForMa
 static lpfnLGBM_BoosterPredictForMatSingleRowFast     fnLGBM_BoosterPredictForMatSingleRowFast     = nullptr;
 static lpfnLGBM_FastConfigFree                        fnLGBM_FastConfigFree                        = nullptr;
 
+static lpfnLGBM_BoosterCalcNumPredict fnLGBM_BoosterCalcNumPredict = nullptr;
+static lpfnLGBM_BoosterPredictForMat  fnLGBM_BoosterPredictForMat  = nullptr;
+
+static lpfnLGBM_DatasetCreateFromCSR fnLGBM_DatasetCreateFromCSR = nullptr;
+
+static lpfnLGBM_DatasetCreateFromCSC fnLGBM_DatasetCreateFromCSC = nullptr;
+
+static lpfnLGBM_DatasetCreateFromFile fnLGBM_DatasetCreateFromFile = nullptr;
+
+static lpfnLGBM_DatasetSaveBinary fnLGBM_DatasetSaveBinary = nullptr;
+
+static lpfnLGBM_BoosterUpdateOneIterCustom fnLGBM_BoosterUpdateOneIterCustom = nullptr;
+static lpfnLGBM_BoosterGetPredict          fnLGBM_BoosterGetPredict          = nullptr;
+static lpfnLGBM_DatasetGetField            fnLGBM_DatasetGetField            = nullptr;
+
+static lpfnLGBM_BoosterGetEvalNames fnLGBM_BoosterGetEvalNames = nullptr;
+
+static lpfnLGBM_BoosterGetCurrentIteration  fnLGBM_BoosterGetCurrentIteration  = nullptr;
+static lpfnLGBM_BoosterRollbackOneIter      fnLGBM_BoosterRollbackOneIter      = nullptr;
+static lpfnLGBM_BoosterNumberOfTotalModel   fnLGBM_BoosterNumberOfTotalModel   = nullptr;
+static lpfnLGBM_BoosterNumModelPerIteration fnLGBM_BoosterNumModelPerIteration = nullptr;
+
+static lpfnLGBM_BoosterCreateFromModelfile fnLGBM_BoosterCreateFromModelfile = nullptr;
+static lpfnLGBM_BoosterSaveModel           fnLGBM_BoosterSaveModel           = nullptr;
+
+static lpfnLGBM_BoosterDumpModel fnLGBM_BoosterDumpModel = nullptr;
+
+static lpfnLGBM_BoosterFeatureImportance fnLGBM_BoosterFeatureImportance = nullptr;
+static lpfnLGBM_BoosterGetFeatureNames   fnLGBM_BoosterGetFeatureNames   = nullptr;
+
+static lpfnLGBM_DatasetCreateByReference      fnLGBM_DatasetCreateByReference      = nullptr;
+static lpfnLGBM_DatasetInitStreaming          fnLGBM_DatasetInitStreaming          = nullptr;
+static lpfnLGBM_DatasetPushRowsWithMetadata   fnLGBM_DatasetPushRowsWithMetadata   = nullptr;
+static lpfnLGBM_DatasetMarkFinished           fnLGBM_DatasetMarkFinished           = nullptr;
+static lpfnLGBM_DatasetSetWaitForManualFinish fnLGBM_DatasetSetWaitForManualFinish = nullptr;
+
+static lpfnLGBM_DatasetGetNumData       fnLGBM_DatasetGetNumData       = nullptr;
+static lpfnLGBM_DatasetGetNumFeature    fnLGBM_DatasetGetNumFeature    = nullptr;
+static lpfnLGBM_DatasetGetFeatureNumBin fnLGBM_DatasetGetFeatureNumBin = nullptr;
+static lpfnLGBM_DatasetGetFeatureNames  fnLGBM_DatasetGetFeatureNames  = nullptr;
+
+static lpfnLGBM_DatasetGetSubset fnLGBM_DatasetGetSubset = nullptr;
+
 // -----------------------------------------------------------------------------
 
 static void savePointers(void *ptr_LGBM_GetLastError,
@@ -166,7 +377,36 @@ static void savePointers(void *ptr_LGBM_GetLastError,
                          void *ptr_LGBM_BoosterLoadModelFromString,
                          void *ptr_LGBM_BoosterPredictForMatSingleRowFastInit,
                          void *ptr_LGBM_BoosterPredictForMatSingleRowFast,
-                         void *ptr_LGBM_FastConfigFree)
+                         void *ptr_LGBM_FastConfigFree,
+                         void *ptr_LGBM_BoosterCalcNumPredict,
+                         void *ptr_LGBM_BoosterPredictForMat,
+                         void *ptr_LGBM_DatasetCreateFromCSR,
+                         void *ptr_LGBM_DatasetCreateFromCSC,
+                         void *ptr_LGBM_DatasetCreateFromFile,
+                         void *ptr_LGBM_DatasetSaveBinary,
+                         void *ptr_LGBM_BoosterUpdateOneIterCustom,
+                         void *ptr_LGBM_BoosterGetPredict,
+                         void *ptr_LGBM_DatasetGetField,
+                         void *ptr_LGBM_BoosterGetEvalNames,
+                         void *ptr_LGBM_BoosterGetCurrentIteration,
+                         void *ptr_LGBM_BoosterRollbackOneIter,
+                         void *ptr_LGBM_BoosterNumberOfTotalModel,
+                         void *ptr_LGBM_BoosterNumModelPerIteration,
+                         void *ptr_LGBM_BoosterCreateFromModelfile,
+                         void *ptr_LGBM_BoosterSaveModel,
+                         void *ptr_LGBM_BoosterDumpModel,
+                         void *ptr_LGBM_BoosterFeatureImportance,
+                         void *ptr_LGBM_BoosterGetFeatureNames,
+                         void *ptr_LGBM_DatasetCreateByReference,
+                         void *ptr_LGBM_DatasetInitStreaming,
+                         void *ptr_LGBM_DatasetPushRowsWithMetadata,
+                         void *ptr_LGBM_DatasetMarkFinished,
+                         void *ptr_LGBM_DatasetSetWaitForManualFinish,
+                         void *ptr_LGBM_DatasetGetNumData,
+                         void *ptr_LGBM_DatasetGetNumFeature,
+                         void *ptr_LGBM_DatasetGetFeatureNumBin,
+                         void *ptr_LGBM_DatasetGetFeatureNames,
+                         void *ptr_LGBM_DatasetGetSubset)
 {
     fnLGBM_GetLastError = (lpfnLGBM_GetLastError)ptr_LGBM_GetLastError;
     fnLGBM_RegisterLogCallback = (lpfnLGBM_RegisterLogCallback)ptr_LGBM_RegisterLogCallback;
@@ -191,6 +431,49 @@ static void savePointers(void *ptr_LGBM_GetLastError,
     fnLGBM_BoosterPredictForMatSingleRowFastInit = (lpfnLGBM_BoosterPredictForMatSingleRowFastInit)ptr_LGBM_BoosterPredictForMatSingleRowFastInit;
     fnLGBM_BoosterPredictForMatSingleRowFast     = (lpfnLGBM_BoosterPredictForMatSingleRowFast    )ptr_LGBM_BoosterPredictForMatSingleRowFast;
     fnLGBM_FastConfigFree                        = (lpfnLGBM_FastConfigFree                       )ptr_LGBM_FastConfigFree;
+
+    fnLGBM_BoosterCalcNumPredict = (lpfnLGBM_BoosterCalcNumPredict)ptr_LGBM_BoosterCalcNumPredict;
+    fnLGBM_BoosterPredictForMat  = (lpfnLGBM_BoosterPredictForMat )ptr_LGBM_BoosterPredictForMat;
+
+    fnLGBM_DatasetCreateFromCSR = (lpfnLGBM_DatasetCreateFromCSR)ptr_LGBM_DatasetCreateFromCSR;
+
+    fnLGBM_DatasetCreateFromCSC = (lpfnLGBM_DatasetCreateFromCSC)ptr_LGBM_DatasetCreateFromCSC;
+
+    fnLGBM_DatasetCreateFromFile = (lpfnLGBM_DatasetCreateFromFile)ptr_LGBM_DatasetCreateFromFile;
+
+    fnLGBM_DatasetSaveBinary = (lpfnLGBM_DatasetSaveBinary)ptr_LGBM_DatasetSaveBinary;
+
+    fnLGBM_BoosterUpdateOneIterCustom = (lpfnLGBM_BoosterUpdateOneIterCustom)ptr_LGBM_BoosterUpdateOneIterCustom;
+    fnLGBM_BoosterGetPredict          = (lpfnLGBM_BoosterGetPredict         )ptr_LGBM_BoosterGetPredict;
+    fnLGBM_DatasetGetField            = (lpfnLGBM_DatasetGetField           )ptr_LGBM_DatasetGetField;
+
+    fnLGBM_BoosterGetEvalNames = (lpfnLGBM_BoosterGetEvalNames)ptr_LGBM_BoosterGetEvalNames;
+
+    fnLGBM_BoosterGetCurrentIteration  = (lpfnLGBM_BoosterGetCurrentIteration )ptr_LGBM_BoosterGetCurrentIteration;
+    fnLGBM_BoosterRollbackOneIter      = (lpfnLGBM_BoosterRollbackOneIter     )ptr_LGBM_BoosterRollbackOneIter;
+    fnLGBM_BoosterNumberOfTotalModel   = (lpfnLGBM_BoosterNumberOfTotalModel  )ptr_LGBM_BoosterNumberOfTotalModel;
+    fnLGBM_BoosterNumModelPerIteration = (lpfnLGBM_BoosterNumModelPerIteration)ptr_LGBM_BoosterNumModelPerIteration;
+
+    fnLGBM_BoosterCreateFromModelfile = (lpfnLGBM_BoosterCreateFromModelfile)ptr_LGBM_BoosterCreateFromModelfile;
+    fnLGBM_BoosterSaveModel           = (lpfnLGBM_BoosterSaveModel          )ptr_LGBM_BoosterSaveModel;
+
+    fnLGBM_BoosterDumpModel = (lpfnLGBM_BoosterDumpModel)ptr_LGBM_BoosterDumpModel;
+
+    fnLGBM_BoosterFeatureImportance = (lpfnLGBM_BoosterFeatureImportance)ptr_LGBM_BoosterFeatureImportance;
+    fnLGBM_BoosterGetFeatureNames   = (lpfnLGBM_BoosterGetFeatureNames  )ptr_LGBM_BoosterGetFeatureNames;
+
+    fnLGBM_DatasetCreateByReference      = (lpfnLGBM_DatasetCreateByReference     )ptr_LGBM_DatasetCreateByReference;
+    fnLGBM_DatasetInitStreaming          = (lpfnLGBM_DatasetInitStreaming         )ptr_LGBM_DatasetInitStreaming;
+    fnLGBM_DatasetPushRowsWithMetadata   = (lpfnLGBM_DatasetPushRowsWithMetadata  )ptr_LGBM_DatasetPushRowsWithMetadata;
+    fnLGBM_DatasetMarkFinished           = (lpfnLGBM_DatasetMarkFinished          )ptr_LGBM_DatasetMarkFinished;
+    fnLGBM_DatasetSetWaitForManualFinish = (lpfnLGBM_DatasetSetWaitForManualFinish)ptr_LGBM_DatasetSetWaitForManualFinish;
+
+    fnLGBM_DatasetGetNumData       = (lpfnLGBM_DatasetGetNumData      )ptr_LGBM_DatasetGetNumData;
+    fnLGBM_DatasetGetNumFeature    = (lpfnLGBM_DatasetGetNumFeature   )ptr_LGBM_DatasetGetNumFeature;
+    fnLGBM_DatasetGetFeatureNumBin = (lpfnLGBM_DatasetGetFeatureNumBin)ptr_LGBM_DatasetGetFeatureNumBin;
+    fnLGBM_DatasetGetFeatureNames  = (lpfnLGBM_DatasetGetFeatureNames )ptr_LGBM_DatasetGetFeatureNames;
+
+    fnLGBM_DatasetGetSubset = (lpfnLGBM_DatasetGetSubset)ptr_LGBM_DatasetGetSubset;
 }
 
 static char* call_LGBM_GetLastError()
@@ -281,6 +564,13 @@ static int call_LGBM_BoosterGetNumFeature(BoosterHandle handle,
     return fnLGBM_BoosterGetNumFeature(handle, out_len);
 }
 
+static int call_LGBM_BoosterGetNumPredict(BoosterHandle handle,
+                                          int data_idx,
+                                          int64_t *out_len)
+{
+    return fnLGBM_BoosterGetNumPredict(handle, data_idx, out_len);
+}
+
 static int call_LGBM_BoosterSaveModelToString(BoosterHandle handle,
                                               int start_iteration,
                                               int num_iteration,
@@ -327,6 +617,268 @@ static int call_LGBM_FastConfigFree(FastConfigHandle handle)
     return fnLGBM_FastConfigFree(handle);
 }
 
+static int call_LGBM_BoosterCalcNumPredict(BoosterHandle handle,
+                                           int num_row,
+                                           int predict_type,
+                                           int start_iteration,
+                                           int num_iteration,
+                                           int64_t* out_len)
+{
+    return fnLGBM_BoosterCalcNumPredict(handle, num_row, predict_type, start_iteration, num_iteration, out_len);
+}
+
+static int call_LGBM_BoosterPredictForMat(BoosterHandle handle,
+                                          const void* data,
+                                          int data_type,
+                                          int32_t nrow,
+                                          int32_t ncol,
+                                          int is_row_major,
+                                          int predict_type,
+                                          int start_iteration,
+                                          int num_iteration,
+                                          const char* parameter,
+                                          int64_t* out_len,
+                                          double* out_result)
+{
+    return fnLGBM_BoosterPredictForMat(handle, data, data_type, nrow, ncol, is_row_major, predict_type,
+                                       start_iteration, num_iteration, parameter, out_len, out_result);
+}
+
+static int call_LGBM_DatasetCreateFromCSR(const void* indptr,
+                                          int indptr_type,
+                                          const int32_t* indices,
+                                          const void* data,
+                                          int data_type,
+                                          int64_t nindptr,
+                                          int64_t nelem,
+                                          int64_t num_col,
+                                          const char* parameters,
+                                          const DatasetHandle reference,
+                                          DatasetHandle* out)
+{
+    return fnLGBM_DatasetCreateFromCSR(indptr, indptr_type, indices, data, data_type, nindptr, nelem, num_col,
+                                       parameters, reference, out);
+}
+
+static int call_LGBM_DatasetCreateFromCSC(const void* col_ptr,
+                                          int col_ptr_type,
+                                          const int32_t* indices,
+                                          const void* data,
+                                          int data_type,
+                                          int64_t ncol_ptr,
+                                          int64_t nelem,
+                                          int64_t num_row,
+                                          const char* parameters,
+                                          const DatasetHandle reference,
+                                          DatasetHandle* out)
+{
+    return fnLGBM_DatasetCreateFromCSC(col_ptr, col_ptr_type, indices, data, data_type, ncol_ptr, nelem,
+                                       num_row, parameters, reference, out);
+}
+
+static int call_LGBM_DatasetCreateFromFile(const char* filename,
+                                           const char* parameters,
+                                           const DatasetHandle reference,
+                                           DatasetHandle* out)
+{
+    return fnLGBM_DatasetCreateFromFile(filename, parameters, reference, out);
+}
+
+static int call_LGBM_DatasetSaveBinary(DatasetHandle handle,
+                                       const char* filename)
+{
+    return fnLGBM_DatasetSaveBinary(handle, filename);
+}
+
+static int call_LGBM_BoosterUpdateOneIterCustom(BoosterHandle handle,
+                                                const float* grad,
+                                                const float* hess,
+                                                int* is_finished)
+{
+    return fnLGBM_BoosterUpdateOneIterCustom(handle, grad, hess, is_finished);
+}
+
+static int call_LGBM_BoosterGetPredict(BoosterHandle handle,
+                                       int data_idx,
+                                       int64_t* out_len,
+                                       double* out_result)
+{
+    return fnLGBM_BoosterGetPredict(handle, data_idx, out_len, out_result);
+}
+
+static int call_LGBM_DatasetGetField(DatasetHandle handle,
+                                     const char* field_name,
+                                     int* out_len,
+                                     const void** out_ptr,
+                                     int* out_type)
+{
+    return fnLGBM_DatasetGetField(handle, field_name, out_len, out_ptr, out_type);
+}
+
+static int call_LGBM_BoosterGetEvalNames(BoosterHandle handle,
+                                         const int len,
+                                         int* out_len,
+                                         const size_t buffer_len,
+                                         size_t* out_buffer_len,
+                                         char** out_strs)
+{
+    return fnLGBM_BoosterGetEvalNames(handle, len, out_len, buffer_len, out_buffer_len, out_strs);
+}
+
+static int call_LGBM_BoosterGetCurrentIteration(BoosterHandle handle,
+                                                int* out_iteration)
+{
+    return fnLGBM_BoosterGetCurrentIteration(handle, out_iteration);
+}
+
+static int call_LGBM_BoosterRollbackOneIter(BoosterHandle handle)
+{
+    return fnLGBM_BoosterRollbackOneIter(handle);
+}
+
+static int call_LGBM_BoosterNumberOfTotalModel(BoosterHandle handle,
+                                               int* out_models)
+{
+    return fnLGBM_BoosterNumberOfTotalModel(handle, out_models);
+}
+
+static int call_LGBM_BoosterNumModelPerIteration(BoosterHandle handle,
+                                                 int* out_tree_per_iteration)
+{
+    return fnLGBM_BoosterNumModelPerIteration(handle, out_tree_per_iteration);
+}
+
+static int call_LGBM_BoosterCreateFromModelfile(const char* filename,
+                                                int* out_num_iterations,
+                                                BoosterHandle* out)
+{
+    return fnLGBM_BoosterCreateFromModelfile(filename, out_num_iterations, out);
+}
+
+static int call_LGBM_BoosterSaveModel(BoosterHandle handle,
+                                      int start_iteration,
+                                      int num_iteration,
+                                      int feature_importance_type,
+                                      const char* filename)
+{
+    return fnLGBM_BoosterSaveModel(handle, start_iteration, num_iteration, feature_importance_type, filename);
+}
+
+static int call_LGBM_BoosterDumpModel(BoosterHandle handle,
+                                      int start_iteration,
+                                      int num_iteration,
+                                      int feature_importance_type,
+                                      int64_t buffer_len,
+                                      int64_t* out_len,
+                                      char* out_str)
+{
+    return fnLGBM_BoosterDumpModel(handle, start_iteration, num_iteration, feature_importance_type,
+                                   buffer_len, out_len, out_str);
+}
+
+static int call_LGBM_BoosterFeatureImportance(BoosterHandle handle,
+                                              int num_iteration,
+                                              int importance_type,
+                                              double* out_results)
+{
+    return fnLGBM_BoosterFeatureImportance(handle, num_iteration, importance_type, out_results);
+}
+
+static int call_LGBM_BoosterGetFeatureNames(BoosterHandle handle,
+                                            const int len,
+                                            int* out_len,
+                                            const size_t buffer_len,
+                                            size_t* out_buffer_len,
+                                            char** out_strs)
+{
+    return fnLGBM_BoosterGetFeatureNames(handle, len, out_len, buffer_len, out_buffer_len, out_strs);
+}
+
+static int call_LGBM_DatasetCreateByReference(const DatasetHandle reference,
+                                              int64_t num_total_row,
+                                              DatasetHandle* out)
+{
+    return fnLGBM_DatasetCreateByReference(reference, num_total_row, out);
+}
+
+static int call_LGBM_DatasetInitStreaming(DatasetHandle dataset,
+                                          int32_t has_weights,
+                                          int32_t has_init_scores,
+                                          int32_t has_queries,
+                                          int32_t nclasses,
+                                          int32_t nthreads,
+                                          int32_t omp_max_threads)
+{
+    return fnLGBM_DatasetInitStreaming(dataset, has_weights, has_init_scores, has_queries, nclasses, nthreads,
+                                       omp_max_threads);
+}
+
+static int call_LGBM_DatasetPushRowsWithMetadata(DatasetHandle dataset,
+                                                 const void* data,
+                                                 int data_type,
+                                                 int32_t nrow,
+                                                 int32_t ncol,
+                                                 int32_t start_row,
+                                                 const float* label,
+                                                 const float* weight,
+                                                 const double* init_score,
+                                                 const int32_t* query,
+                                                 int32_t tid)
+{
+    return fnLGBM_DatasetPushRowsWithMetadata(dataset, data, data_type, nrow, ncol, start_row, label, weight,
+                                              init_score, query, tid);
+}
+
+static int call_LGBM_DatasetMarkFinished(DatasetHandle dataset)
+{
+    return fnLGBM_DatasetMarkFinished(dataset);
+}
+
+static int call_LGBM_DatasetSetWaitForManualFinish(DatasetHandle dataset,
+                                                   int wait)
+{
+    return fnLGBM_DatasetSetWaitForManualFinish(dataset, wait);
+}
+
+static int call_LGBM_DatasetGetNumData(DatasetHandle handle,
+                                       int* out)
+{
+    return fnLGBM_DatasetGetNumData(handle, out);
+}
+
+static int call_LGBM_DatasetGetNumFeature(DatasetHandle handle,
+                                          int* out)
+{
+    return fnLGBM_DatasetGetNumFeature(handle, out);
+}
+
+static int call_LGBM_DatasetGetFeatureNumBin(DatasetHandle handle,
+                                             int feature,
+                                             int* out)
+{
+    return fnLGBM_DatasetGetFeatureNumBin(handle, feature, out);
+}
+
+static int call_LGBM_DatasetGetFeatureNames(DatasetHandle handle,
+                                            const int len,
+                                            int* num_feature_names,
+                                            const size_t buffer_len,
+                                            size_t* out_buffer_len,
+                                            char** feature_names)
+{
+    return fnLGBM_DatasetGetFeatureNames(handle, len, num_feature_names, buffer_len, out_buffer_len,
+                                         feature_names);
+}
+
+static int call_LGBM_DatasetGetSubset(const DatasetHandle handle,
+                                      const int32_t* used_row_indices,
+                                      int32_t num_used_row_indices,
+                                      const char* parameters,
+                                      DatasetHandle* out)
+{
+    return fnLGBM_DatasetGetSubset(handle, used_row_indices, num_used_row_indices, parameters, out);
+}
+
 extern void goLoggerCallback(char*);
 
 static void initLoggerCallback()
@@ -339,6 +891,7 @@ import "C"
 // -----------------------------------------------------------------------------
 
 var errInvalidHandle = errors.New("invalid handle")
+var errInvalidPredictType = errors.New("invalid predict type parameter")
 
 var loggerCh chan string
 
@@ -359,8 +912,9 @@ func goLoggerCallback(cMsg *C.char) {
 	}
 }
 
-func datasetCreateFromMat(features []float64, rowsCount int, parameters string, refHandle unsafe.Pointer) (unsafe.Pointer, error) {
+func datasetCreateFromMat(features []float64, rowsCount int, rowMajor bool, parameters string, refHandle unsafe.Pointer) (unsafe.Pointer, error) {
 	var handle unsafe.Pointer
+	var isRowMajor int32
 
 	if len(features) == 0 || rowsCount <= 0 {
 		return nil, errors.New("no data provided or number of rows is not positive")
@@ -372,6 +926,10 @@ func datasetCreateFromMat(features []float64, rowsCount int, parameters string,
 		return nil, errors.New("feature is not a matrix")
 	}
 
+	if rowMajor {
+		isRowMajor = 1
+	}
+
 	// Initialize engine
 	if err := lazyInitialize(); err != nil {
 		return nil, err
@@ -391,7 +949,7 @@ func datasetCreateFromMat(features []float64, rowsCount int, parameters string,
 		C.int(C.C_API_DTYPE_FLOAT64),
 		C.int32_t(rowsCount),
 		C.int32_t(featuresCount),
-		C.int32_t(1),
+		C.int32_t(isRowMajor),
 		cParams,
 		C.DatasetHandle(refHandle),
 		(*C.DatasetHandle)(&handle),
@@ -404,96 +962,564 @@ func datasetCreateFromMat(features []float64, rowsCount int, parameters string,
 	return handle, nil
 }
 
-func datasetFree(handle unsafe.Pointer) {
-	if handle != nil {
-		_ = C.call_LGBM_DatasetFree(
-			C.DatasetHandle(handle),
-		)
+func datasetCreateFromCSR(indPtr []int64, indices []int32, values []float64, columnsCount int, parameters string, refHandle unsafe.Pointer) (unsafe.Pointer, error) {
+	var handle unsafe.Pointer
+	var indicesPtr unsafe.Pointer
+	var valuesPtr unsafe.Pointer
+
+	if len(indPtr) < 2 || columnsCount <= 0 {
+		return nil, errors.New("no data provided or number of columns is not positive")
+	}
+	if len(indices) != len(values) || int64(len(values)) != indPtr[len(indPtr)-1] {
+		return nil, errors.New("sparse data is not consistent")
+	}
+	if len(values) > 0 {
+		indicesPtr = unsafe.Pointer(&indices[0])
+		valuesPtr = unsafe.Pointer(&values[0])
 	}
-}
 
-func datasetSetFieldFloat64(handle unsafe.Pointer, field string, values []float64) error {
-	if handle == nil {
-		return errInvalidHandle
+	// Initialize engine
+	if err := lazyInitialize(); err != nil {
+		return nil, err
 	}
 
 	// Convert parameters
-	cFieldName := C.CString(field)
-	defer C.free(unsafe.Pointer(cFieldName))
+	cParams := C.CString(parameters)
+	defer C.free(unsafe.Pointer(cParams))
 
 	// Lock thread
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
-	// Set field
-	ret := C.call_LGBM_DatasetSetField(
-		C.DatasetHandle(handle),
-		cFieldName,
-		unsafe.Pointer(&values[0]),
-		C.int(len(values)),
+	// Create the dataset object
+	ret := C.call_LGBM_DatasetCreateFromCSR(
+		unsafe.Pointer(&indPtr[0]),
+		C.int(C.C_API_DTYPE_INT64),
+		(*C.int32_t)(indicesPtr),
+		valuesPtr,
 		C.int(C.C_API_DTYPE_FLOAT64),
+		C.int64_t(len(indPtr)),
+		C.int64_t(len(values)),
+		C.int64_t(columnsCount),
+		cParams,
+		C.DatasetHandle(refHandle),
+		(*C.DatasetHandle)(&handle),
 	)
-	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(indPtr) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(indices)
+	runtime.KeepAlive(values)
 	if ret != 0 {
-		return getLastError()
+		return nil, getLastError()
 	}
 
 	// Done
-	return nil
+	return handle, nil
 }
 
-func datasetSetFieldFloat32(handle unsafe.Pointer, field string, values []float32) error {
-	if handle == nil {
-		return errInvalidHandle
+func datasetCreateFromCSC(colPtr []int64, indices []int32, values []float64, rowsCount int, parameters string, refHandle unsafe.Pointer) (unsafe.Pointer, error) {
+	var handle unsafe.Pointer
+	var indicesPtr unsafe.Pointer
+	var valuesPtr unsafe.Pointer
+
+	if len(colPtr) < 2 || rowsCount <= 0 {
+		return nil, errors.New("no data provided or number of rows is not positive")
+	}
+	if len(indices) != len(values) || int64(len(values)) != colPtr[len(colPtr)-1] {
+		return nil, errors.New("sparse data is not consistent")
+	}
+	if len(values) > 0 {
+		indicesPtr = unsafe.Pointer(&indices[0])
+		valuesPtr = unsafe.Pointer(&values[0])
+	}
+
+	// Initialize engine
+	if err := lazyInitialize(); err != nil {
+		return nil, err
 	}
 
 	// Convert parameters
-	cFieldName := C.CString(field)
-	defer C.free(unsafe.Pointer(cFieldName))
+	cParams := C.CString(parameters)
+	defer C.free(unsafe.Pointer(cParams))
 
 	// Lock thread
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
-	// Set field
-	ret := C.call_LGBM_DatasetSetField(
-		C.DatasetHandle(handle),
-		cFieldName,
-		unsafe.Pointer(&values[0]),
-		C.int(len(values)),
-		C.int(C.C_API_DTYPE_FLOAT32),
+	// Create the dataset object
+	ret := C.call_LGBM_DatasetCreateFromCSC(
+		unsafe.Pointer(&colPtr[0]),
+		C.int(C.C_API_DTYPE_INT64),
+		(*C.int32_t)(indicesPtr),
+		valuesPtr,
+		C.int(C.C_API_DTYPE_FLOAT64),
+		C.int64_t(len(colPtr)),
+		C.int64_t(len(values)),
+		C.int64_t(rowsCount),
+		cParams,
+		C.DatasetHandle(refHandle),
+		(*C.DatasetHandle)(&handle),
 	)
-	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(colPtr) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(indices)
+	runtime.KeepAlive(values)
 	if ret != 0 {
-		return getLastError()
+		return nil, getLastError()
 	}
 
 	// Done
-	return nil
+	return handle, nil
 }
 
-func datasetSetFieldInt32(handle unsafe.Pointer, field string, values []int32) error {
-	if handle == nil {
-		return errInvalidHandle
+func datasetCreateFromFile(filename string, parameters string, refHandle unsafe.Pointer) (unsafe.Pointer, error) {
+	var handle unsafe.Pointer
+
+	if len(filename) == 0 {
+		return nil, errors.New("no filename provided")
+	}
+
+	// Initialize engine
+	if err := lazyInitialize(); err != nil {
+		return nil, err
 	}
 
 	// Convert parameters
-	cFieldName := C.CString(field)
-	defer C.free(unsafe.Pointer(cFieldName))
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	cParams := C.CString(parameters)
+	defer C.free(unsafe.Pointer(cParams))
 
 	// Lock thread
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
-	// Set field
+	// Create the dataset object
+	ret := C.call_LGBM_DatasetCreateFromFile(
+		cFilename,
+		cParams,
+		C.DatasetHandle(refHandle),
+		(*C.DatasetHandle)(&handle),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return handle, nil
+}
+
+func datasetFree(handle unsafe.Pointer) {
+	if handle != nil {
+		_ = C.call_LGBM_DatasetFree(
+			C.DatasetHandle(handle),
+		)
+	}
+}
+
+func datasetSetFieldFloat64(handle unsafe.Pointer, field string, values []float64) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Convert parameters
+	cFieldName := C.CString(field)
+	defer C.free(unsafe.Pointer(cFieldName))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Set field
+	ret := C.call_LGBM_DatasetSetField(
+		C.DatasetHandle(handle),
+		cFieldName,
+		unsafe.Pointer(&values[0]),
+		C.int(len(values)),
+		C.int(C.C_API_DTYPE_FLOAT64),
+	)
+	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetSetFieldFloat32(handle unsafe.Pointer, field string, values []float32) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Convert parameters
+	cFieldName := C.CString(field)
+	defer C.free(unsafe.Pointer(cFieldName))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Set field
+	ret := C.call_LGBM_DatasetSetField(
+		C.DatasetHandle(handle),
+		cFieldName,
+		unsafe.Pointer(&values[0]),
+		C.int(len(values)),
+		C.int(C.C_API_DTYPE_FLOAT32),
+	)
+	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetSetFieldInt32(handle unsafe.Pointer, field string, values []int32) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Convert parameters
+	cFieldName := C.CString(field)
+	defer C.free(unsafe.Pointer(cFieldName))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Set field
 	ret := C.call_LGBM_DatasetSetField(
 		C.DatasetHandle(handle),
-		cFieldName,
-		unsafe.Pointer(&values[0]),
-		C.int(len(values)),
-		C.int(C.C_API_DTYPE_INT32),
+		cFieldName,
+		unsafe.Pointer(&values[0]),
+		C.int(len(values)),
+		C.int(C.C_API_DTYPE_INT32),
+	)
+	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetGetField(handle unsafe.Pointer, field string) ([]float64, []int32, error) {
+	var outLen int32
+	var outPtr unsafe.Pointer
+	var outType int32
+
+	if handle == nil {
+		return nil, nil, errInvalidHandle
+	}
+
+	// Convert parameters
+	cFieldName := C.CString(field)
+	defer C.free(unsafe.Pointer(cFieldName))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get field
+	ret := C.call_LGBM_DatasetGetField(
+		C.DatasetHandle(handle),
+		cFieldName,
+		(*C.int)(&outLen),
+		&outPtr,
+		(*C.int)(&outType),
+	)
+	if ret != 0 {
+		return nil, nil, getLastError()
+	}
+	if outLen <= 0 || outPtr == nil {
+		return nil, nil, nil
+	}
+
+	// Copy the values because they are owned by the library
+	switch outType {
+	case C.C_API_DTYPE_FLOAT32:
+		values := make([]float64, int(outLen))
+		for idx, value := range unsafe.Slice((*float32)(outPtr), int(outLen)) {
+			values[idx] = float64(value)
+		}
+		return values, nil, nil
+
+	case C.C_API_DTYPE_FLOAT64:
+		values := make([]float64, int(outLen))
+		copy(values, unsafe.Slice((*float64)(outPtr), int(outLen)))
+		return values, nil, nil
+
+	case C.C_API_DTYPE_INT32:
+		values := make([]int32, int(outLen))
+		copy(values, unsafe.Slice((*int32)(outPtr), int(outLen)))
+		return nil, values, nil
+	}
+
+	// Done
+	return nil, nil, errors.New("unsupported field data type")
+}
+
+func datasetGetNumData(handle unsafe.Pointer) (int, error) {
+	var rowsCount int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of rows
+	ret := C.call_LGBM_DatasetGetNumData(
+		C.DatasetHandle(handle),
+		(*C.int)(&rowsCount),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(rowsCount), nil
+}
+
+func datasetGetNumFeature(handle unsafe.Pointer) (int, error) {
+	var featuresCount int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of features
+	ret := C.call_LGBM_DatasetGetNumFeature(
+		C.DatasetHandle(handle),
+		(*C.int)(&featuresCount),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(featuresCount), nil
+}
+
+func datasetGetFeatureNumBin(handle unsafe.Pointer, featureIdx int) (int, error) {
+	var binsCount int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of bins
+	ret := C.call_LGBM_DatasetGetFeatureNumBin(
+		C.DatasetHandle(handle),
+		C.int(featureIdx),
+		(*C.int)(&binsCount),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(binsCount), nil
+}
+
+func datasetGetFeatureNames(handle unsafe.Pointer) ([]string, error) {
+	featuresCount, err := datasetGetNumFeature(handle)
+	if err != nil {
+		return nil, err
+	}
+	if featuresCount <= 0 {
+		return make([]string, 0), nil
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get names
+	return getStringArray(featuresCount, func(cArray []*C.char, bufferLen int, outLen *int32, outBufferLen *C.size_t) C.int {
+		return C.call_LGBM_DatasetGetFeatureNames(
+			C.DatasetHandle(handle),
+			C.int(len(cArray)),
+			(*C.int)(outLen),
+			C.size_t(bufferLen),
+			outBufferLen,
+			(**C.char)(unsafe.Pointer(&cArray[0])),
+		)
+	})
+}
+
+func datasetSetFeatureNames(handle unsafe.Pointer, names []string) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Convert parameters
+	cNamesArray := make([]*C.char, len(names))
+	for idx, name := range names {
+		cNamesArray[idx] = C.CString(name)
+	}
+	defer func() {
+		for idx := range names {
+			C.free(unsafe.Pointer(cNamesArray[idx]))
+		}
+	}()
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Set field
+	ret := C.call_LGBM_DatasetSetFeatureNames(
+		C.DatasetHandle(handle),
+		(**C.char)(unsafe.Pointer(&cNamesArray[0])),
+		C.int(len(names)),
+	)
+	runtime.KeepAlive(names) // Yes, keep-alive should be placed after the position where is used
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetSaveBinary(handle unsafe.Pointer, filename string) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+	if len(filename) == 0 {
+		return errors.New("no filename provided")
+	}
+
+	// Convert parameters
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Save the dataset
+	ret := C.call_LGBM_DatasetSaveBinary(
+		C.DatasetHandle(handle),
+		cFilename,
+	)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetGetSubset(handle unsafe.Pointer, indices []int32, parameters string) (unsafe.Pointer, error) {
+	var subsetHandle unsafe.Pointer
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+	if len(indices) == 0 {
+		return nil, errors.New("no indices provided")
+	}
+
+	// Convert parameters
+	cParams := C.CString(parameters)
+	defer C.free(unsafe.Pointer(cParams))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Create the subset
+	ret := C.call_LGBM_DatasetGetSubset(
+		C.DatasetHandle(handle),
+		(*C.int32_t)(unsafe.Pointer(&indices[0])),
+		C.int32_t(len(indices)),
+		cParams,
+		(*C.DatasetHandle)(&subsetHandle),
+	)
+	runtime.KeepAlive(indices)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return subsetHandle, nil
+}
+
+func datasetCreateByReference(refHandle unsafe.Pointer, rowsCount int) (unsafe.Pointer, error) {
+	var handle unsafe.Pointer
+
+	if refHandle == nil {
+		return nil, errInvalidHandle
+	}
+	if rowsCount <= 0 {
+		return nil, errors.New("number of rows is not positive")
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Create the dataset object
+	ret := C.call_LGBM_DatasetCreateByReference(
+		C.DatasetHandle(refHandle),
+		C.int64_t(rowsCount),
+		(*C.DatasetHandle)(&handle),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return handle, nil
+}
+
+func datasetInitStreaming(handle unsafe.Pointer, hasWeights bool, hasInitScores bool, hasQueries bool, classesCount int) error {
+	var hasWeightsFlag int32
+	var hasInitScoresFlag int32
+	var hasQueriesFlag int32
+
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	if hasWeights {
+		hasWeightsFlag = 1
+	}
+	if hasInitScores {
+		hasInitScoresFlag = 1
+	}
+	if hasQueries {
+		hasQueriesFlag = 1
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Prepare the dataset for streaming. Rows are pushed by a single thread.
+	ret := C.call_LGBM_DatasetInitStreaming(
+		C.DatasetHandle(handle),
+		C.int32_t(hasWeightsFlag),
+		C.int32_t(hasInitScoresFlag),
+		C.int32_t(hasQueriesFlag),
+		C.int32_t(classesCount),
+		C.int32_t(1),
+		C.int32_t(-1),
 	)
-	runtime.KeepAlive(values) // Yes, keep-alive should be placed after the position where is used
 	if ret != 0 {
 		return getLastError()
 	}
@@ -502,33 +1528,108 @@ func datasetSetFieldInt32(handle unsafe.Pointer, field string, values []int32) e
 	return nil
 }
 
-func datasetSetFeatureNames(handle unsafe.Pointer, names []string) error {
+func datasetSetWaitForManualFinish(handle unsafe.Pointer, wait bool) error {
+	var waitFlag int
+
 	if handle == nil {
 		return errInvalidHandle
 	}
 
-	// Convert parameters
-	cNamesArray := make([]*C.char, len(names))
-	for idx, name := range names {
-		cNamesArray[idx] = C.CString(name)
+	if wait {
+		waitFlag = 1
 	}
-	defer func() {
-		for idx := range names {
-			C.free(unsafe.Pointer(cNamesArray[idx]))
-		}
-	}()
 
 	// Lock thread
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
-	// Set field
-	ret := C.call_LGBM_DatasetSetFeatureNames(
+	// Set the flag
+	ret := C.call_LGBM_DatasetSetWaitForManualFinish(
+		C.DatasetHandle(handle),
+		C.int(waitFlag),
+	)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+// datasetPushRowsWithMetadata pushes a row-major chunk of rows. Init scores must be stored class-major, that
+// is, the scores of the first class for every row, then the ones of the second class, and so on.
+func datasetPushRowsWithMetadata(
+	handle unsafe.Pointer, features []float64, rowsCount int, startRow int,
+	labels []float32, weights []float32, initScores []float64, queries []int32,
+) error {
+	var weightsPtr *C.float
+	var initScoresPtr *C.double
+	var queriesPtr *C.int32_t
+
+	if handle == nil {
+		return errInvalidHandle
+	}
+	if len(features) == 0 || rowsCount <= 0 || len(features)%rowsCount != 0 {
+		return errors.New("no data provided or feature is not a matrix")
+	}
+	if len(labels) != rowsCount {
+		return errors.New("the number of labels does not match the number of rows")
+	}
+
+	if len(weights) > 0 {
+		weightsPtr = (*C.float)(unsafe.Pointer(&weights[0]))
+	}
+	if len(initScores) > 0 {
+		initScoresPtr = (*C.double)(unsafe.Pointer(&initScores[0]))
+	}
+	if len(queries) > 0 {
+		queriesPtr = (*C.int32_t)(unsafe.Pointer(&queries[0]))
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Push rows
+	ret := C.call_LGBM_DatasetPushRowsWithMetadata(
+		C.DatasetHandle(handle),
+		unsafe.Pointer(&features[0]),
+		C.int(C.C_API_DTYPE_FLOAT64),
+		C.int32_t(rowsCount),
+		C.int32_t(len(features)/rowsCount),
+		C.int32_t(startRow),
+		(*C.float)(unsafe.Pointer(&labels[0])),
+		weightsPtr,
+		initScoresPtr,
+		queriesPtr,
+		C.int32_t(0),
+	)
+	runtime.KeepAlive(features)
+	runtime.KeepAlive(labels)
+	runtime.KeepAlive(weights)
+	runtime.KeepAlive(initScores)
+	runtime.KeepAlive(queries)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func datasetMarkFinished(handle unsafe.Pointer) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Finish loading
+	ret := C.call_LGBM_DatasetMarkFinished(
 		C.DatasetHandle(handle),
-		(**C.char)(unsafe.Pointer(&cNamesArray[0])),
-		C.int(len(names)),
 	)
-	runtime.KeepAlive(names) // Yes, keep-alive should be placed after the position where is used
 	if ret != 0 {
 		return getLastError()
 	}
@@ -620,6 +1721,130 @@ func boosterUpdateOneIter(handle unsafe.Pointer) (bool, error) {
 	return isFinished != 0, nil
 }
 
+func boosterUpdateOneIterCustom(handle unsafe.Pointer, grad []float32, hess []float32) (bool, error) {
+	var isFinished int32
+
+	if handle == nil {
+		return false, errInvalidHandle
+	}
+	if len(grad) == 0 || len(grad) != len(hess) {
+		return false, errors.New("gradients and hessians must have the same non-zero length")
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Update one iteration
+	ret := C.call_LGBM_BoosterUpdateOneIterCustom(
+		C.BoosterHandle(handle),
+		(*C.float)(unsafe.Pointer(&grad[0])),
+		(*C.float)(unsafe.Pointer(&hess[0])),
+		(*C.int)(&isFinished),
+	)
+	runtime.KeepAlive(grad) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(hess)
+	if ret != 0 {
+		return false, getLastError()
+	}
+
+	// Done
+	return isFinished != 0, nil
+}
+
+func boosterGetCurrentIteration(handle unsafe.Pointer) (int, error) {
+	var iteration int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the current iteration
+	ret := C.call_LGBM_BoosterGetCurrentIteration(
+		C.BoosterHandle(handle),
+		(*C.int)(&iteration),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(iteration), nil
+}
+
+func boosterRollbackOneIter(handle unsafe.Pointer) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Rollback one iteration
+	ret := C.call_LGBM_BoosterRollbackOneIter(
+		C.BoosterHandle(handle),
+	)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
+func boosterNumberOfTotalModel(handle unsafe.Pointer) (int, error) {
+	var modelsCount int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of models
+	ret := C.call_LGBM_BoosterNumberOfTotalModel(
+		C.BoosterHandle(handle),
+		(*C.int)(&modelsCount),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(modelsCount), nil
+}
+
+func boosterNumModelPerIteration(handle unsafe.Pointer) (int, error) {
+	var modelsCount int32
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of models per iteration
+	ret := C.call_LGBM_BoosterNumModelPerIteration(
+		C.BoosterHandle(handle),
+		(*C.int)(&modelsCount),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(modelsCount), nil
+}
+
 func boosterGetEval(handle unsafe.Pointer, dataIndex int) ([]float64, error) {
 	var outLen int32
 
@@ -658,17 +1883,162 @@ func boosterGetEval(handle unsafe.Pointer, dataIndex int) ([]float64, error) {
 	}
 
 	// Done
-	return results, nil
+	return results, nil
+}
+
+func boosterGetPredict(handle unsafe.Pointer, dataIndex int) ([]float64, error) {
+	var outLen int64
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get count
+	ret := C.call_LGBM_BoosterGetNumPredict(
+		C.BoosterHandle(handle),
+		C.int(dataIndex),
+		(*C.int64_t)(&outLen),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+	if outLen <= 0 {
+		return make([]float64, 0), nil
+	}
+
+	// Make room for results
+	results := make([]float64, int(outLen))
+
+	// Get predictions
+	ret = C.call_LGBM_BoosterGetPredict(
+		C.BoosterHandle(handle),
+		C.int(dataIndex),
+		(*C.int64_t)(&outLen),
+		(*C.double)(unsafe.Pointer(&results[0])),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return results, nil
+}
+
+func boosterGetEvalNames(handle unsafe.Pointer) ([]string, error) {
+	var count int32
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get count
+	ret := C.call_LGBM_BoosterGetEvalCounts(
+		C.BoosterHandle(handle),
+		(*C.int)(&count),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+	if count <= 0 {
+		return make([]string, 0), nil
+	}
+
+	// Get names
+	return getStringArray(int(count), func(cArray []*C.char, bufferLen int, outLen *int32, outBufferLen *C.size_t) C.int {
+		return C.call_LGBM_BoosterGetEvalNames(
+			C.BoosterHandle(handle),
+			C.int(len(cArray)),
+			(*C.int)(outLen),
+			C.size_t(bufferLen),
+			outBufferLen,
+			(**C.char)(unsafe.Pointer(&cArray[0])),
+		)
+	})
+}
+
+func boosterSaveModelToString(handle unsafe.Pointer, startIteration int, numIteration int, featureImportance int) (string, error) {
+	buf, err := boosterSaveModelToBytes(handle, startIteration, numIteration, featureImportance)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func boosterSaveModelToBytes(handle unsafe.Pointer, startIteration int, numIteration int, featureImportance int) ([]byte, error) {
+	var outLen int64
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+	if featureImportance != C.C_API_FEATURE_IMPORTANCE_SPLIT && featureImportance != C.C_API_FEATURE_IMPORTANCE_GAIN {
+		return nil, errors.New("invalid feature importance parameter")
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Create room for output
+	buf := make([]byte, 32768)
+	cBuf := (*C.char)(unsafe.Pointer(&buf[0]))
+
+	// Save the model into a string
+	ret := C.call_LGBM_BoosterSaveModelToString(
+		C.BoosterHandle(handle),
+		C.int(startIteration),
+		C.int(numIteration),
+		C.int(featureImportance),
+		C.longlong(len(buf)),
+		(*C.longlong)(&outLen),
+		cBuf,
+	)
+	// If not enough space
+	if ret == 0 && int(outLen) >= len(buf) {
+		// Build a new room with sufficient space
+		buf = make([]byte, int(outLen)+1)
+		cBuf = (*C.char)(unsafe.Pointer(&buf[0]))
+
+		// Save the model into a string
+		ret = C.call_LGBM_BoosterSaveModelToString(
+			C.BoosterHandle(handle),
+			C.int(startIteration),
+			C.int(numIteration),
+			C.int(featureImportance),
+			C.longlong(len(buf)),
+			(*C.longlong)(&outLen),
+			cBuf,
+		)
+	}
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	for outLen > 0 {
+		if buf[int(outLen)-1] != 0 {
+			break
+		}
+		outLen -= 1
+	}
+	return buf[:int(outLen)], nil
 }
 
-func boosterSaveModelToString(handle unsafe.Pointer, featureImportance int) (string, error) {
+func boosterDumpModel(handle unsafe.Pointer, startIteration int, numIteration int, featureImportance int) ([]byte, error) {
 	var outLen int64
 
 	if handle == nil {
-		return "", errInvalidHandle
+		return nil, errInvalidHandle
 	}
 	if featureImportance != C.C_API_FEATURE_IMPORTANCE_SPLIT && featureImportance != C.C_API_FEATURE_IMPORTANCE_GAIN {
-		return "", errors.New("invalid feature importance parameter")
+		return nil, errors.New("invalid feature importance parameter")
 	}
 
 	// Lock thread
@@ -679,14 +2049,14 @@ func boosterSaveModelToString(handle unsafe.Pointer, featureImportance int) (str
 	buf := make([]byte, 32768)
 	cBuf := (*C.char)(unsafe.Pointer(&buf[0]))
 
-	// Save the model into a string
-	ret := C.call_LGBM_BoosterSaveModelToString(
+	// Dump the model into a JSON string
+	ret := C.call_LGBM_BoosterDumpModel(
 		C.BoosterHandle(handle),
-		C.int(0),
-		C.int(0),
+		C.int(startIteration),
+		C.int(numIteration),
 		C.int(featureImportance),
-		C.longlong(len(buf)),
-		(*C.longlong)(&outLen),
+		C.int64_t(len(buf)),
+		(*C.int64_t)(&outLen),
 		cBuf,
 	)
 	// If not enough space
@@ -695,19 +2065,19 @@ func boosterSaveModelToString(handle unsafe.Pointer, featureImportance int) (str
 		buf = make([]byte, int(outLen)+1)
 		cBuf = (*C.char)(unsafe.Pointer(&buf[0]))
 
-		// Save the model into a string
-		ret = C.call_LGBM_BoosterSaveModelToString(
+		// Dump the model into a JSON string
+		ret = C.call_LGBM_BoosterDumpModel(
 			C.BoosterHandle(handle),
-			C.int(0),
-			C.int(0),
+			C.int(startIteration),
+			C.int(numIteration),
 			C.int(featureImportance),
-			C.longlong(len(buf)),
-			(*C.longlong)(&outLen),
+			C.int64_t(len(buf)),
+			(*C.int64_t)(&outLen),
 			cBuf,
 		)
 	}
 	if ret != 0 {
-		return "", getLastError()
+		return nil, getLastError()
 	}
 
 	// Done
@@ -717,7 +2087,42 @@ func boosterSaveModelToString(handle unsafe.Pointer, featureImportance int) (str
 		}
 		outLen -= 1
 	}
-	return string(buf[:int(outLen)]), nil
+	return buf[:int(outLen)], nil
+}
+
+func boosterSaveModel(handle unsafe.Pointer, startIteration int, numIteration int, featureImportance int, filename string) error {
+	if handle == nil {
+		return errInvalidHandle
+	}
+	if featureImportance != C.C_API_FEATURE_IMPORTANCE_SPLIT && featureImportance != C.C_API_FEATURE_IMPORTANCE_GAIN {
+		return errors.New("invalid feature importance parameter")
+	}
+	if len(filename) == 0 {
+		return errors.New("no filename provided")
+	}
+
+	// Convert parameters
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Save the model into a file
+	ret := C.call_LGBM_BoosterSaveModel(
+		C.BoosterHandle(handle),
+		C.int(startIteration),
+		C.int(numIteration),
+		C.int(featureImportance),
+		cFilename,
+	)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
 }
 
 func boosterLoadModelFromString(data string) (unsafe.Pointer, error) {
@@ -751,6 +2156,74 @@ func boosterLoadModelFromString(data string) (unsafe.Pointer, error) {
 	return handle, nil
 }
 
+func boosterLoadModelFromBytes(data []byte) (unsafe.Pointer, error) {
+	var outNumIterations int32
+	var handle unsafe.Pointer
+
+	// The library expects a null-terminated string
+	if len(data) == 0 || data[len(data)-1] != 0 {
+		data = append(data, 0)
+	}
+
+	// Initialize engine
+	if err := lazyInitialize(); err != nil {
+		return nil, err
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Create the model from the provided data
+	ret := C.call_LGBM_BoosterLoadModelFromString(
+		(*C.char)(unsafe.Pointer(&data[0])),
+		(*C.int)(&outNumIterations),
+		(*C.BoosterHandle)(&handle),
+	)
+	runtime.KeepAlive(data) // Yes, keep-alive should be placed after the position where is used
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return handle, nil
+}
+
+func boosterCreateFromModelFile(filename string) (unsafe.Pointer, error) {
+	var outNumIterations int32
+	var handle unsafe.Pointer
+
+	if len(filename) == 0 {
+		return nil, errors.New("no filename provided")
+	}
+
+	// Initialize engine
+	if err := lazyInitialize(); err != nil {
+		return nil, err
+	}
+
+	// Convert parameters
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Create the model from the provided file
+	ret := C.call_LGBM_BoosterCreateFromModelfile(
+		cFilename,
+		(*C.int)(&outNumIterations),
+		(*C.BoosterHandle)(&handle),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return handle, nil
+}
+
 func boosterGetFeaturesCount(handle unsafe.Pointer) (int, error) {
 	var featuresCount int32
 
@@ -775,6 +2248,86 @@ func boosterGetFeaturesCount(handle unsafe.Pointer) (int, error) {
 	return int(featuresCount), nil
 }
 
+func boosterGetFeatureNames(handle unsafe.Pointer) ([]string, error) {
+	var featuresCount int32
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of features
+	ret := C.call_LGBM_BoosterGetNumFeature(
+		C.BoosterHandle(handle),
+		(*C.int)(&featuresCount),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+	if featuresCount <= 0 {
+		return make([]string, 0), nil
+	}
+
+	// Get names
+	return getStringArray(int(featuresCount), func(cArray []*C.char, bufferLen int, outLen *int32, outBufferLen *C.size_t) C.int {
+		return C.call_LGBM_BoosterGetFeatureNames(
+			C.BoosterHandle(handle),
+			C.int(len(cArray)),
+			(*C.int)(outLen),
+			C.size_t(bufferLen),
+			outBufferLen,
+			(**C.char)(unsafe.Pointer(&cArray[0])),
+		)
+	})
+}
+
+func boosterFeatureImportance(handle unsafe.Pointer, numIteration int, featureImportance int) ([]float64, error) {
+	var featuresCount int32
+
+	if handle == nil {
+		return nil, errInvalidHandle
+	}
+	if featureImportance != C.C_API_FEATURE_IMPORTANCE_SPLIT && featureImportance != C.C_API_FEATURE_IMPORTANCE_GAIN {
+		return nil, errors.New("invalid feature importance parameter")
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get the number of features
+	ret := C.call_LGBM_BoosterGetNumFeature(
+		C.BoosterHandle(handle),
+		(*C.int)(&featuresCount),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+	if featuresCount <= 0 {
+		return make([]float64, 0), nil
+	}
+
+	// Make room for results
+	results := make([]float64, int(featuresCount))
+
+	// Get feature importance
+	ret = C.call_LGBM_BoosterFeatureImportance(
+		C.BoosterHandle(handle),
+		C.int(numIteration),
+		C.int(featureImportance),
+		(*C.double)(unsafe.Pointer(&results[0])),
+	)
+	if ret != 0 {
+		return nil, getLastError()
+	}
+
+	// Done
+	return results, nil
+}
+
 func boosterGetClassesCount(handle unsafe.Pointer) (int, error) {
 	var classesCount int32
 
@@ -795,23 +2348,21 @@ func boosterGetClassesCount(handle unsafe.Pointer) (int, error) {
 	return int(classesCount), nil
 }
 
-func boosterPredictForMatSingleRowFastInit(handle unsafe.Pointer, rawScore bool, parameters string) (unsafe.Pointer, error) {
+func boosterPredictForMatSingleRowFastInit(handle unsafe.Pointer, predictType int, startIteration int, numIteration int, parameters string) (unsafe.Pointer, error) {
 	var featuresCount int32
 	var fastPredictPtr unsafe.Pointer
 
 	if handle == nil {
 		return nil, errInvalidHandle
 	}
+	if !isValidPredictType(predictType) {
+		return nil, errInvalidPredictType
+	}
 
 	// Convert parameters
 	cParams := C.CString(parameters)
 	defer C.free(unsafe.Pointer(cParams))
 
-	predictType := C.C_API_PREDICT_NORMAL
-	if rawScore {
-		predictType = C.C_API_PREDICT_RAW_SCORE
-	}
-
 	// Lock thread
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
@@ -829,8 +2380,8 @@ func boosterPredictForMatSingleRowFastInit(handle unsafe.Pointer, rawScore bool,
 	ret = C.call_LGBM_BoosterPredictForMatSingleRowFastInit(
 		C.BoosterHandle(handle),
 		C.int(predictType),
-		C.int(0),
-		C.int(-1),
+		C.int(startIteration),
+		C.int(numIteration),
 		C.int(C.C_API_DTYPE_FLOAT64),
 		C.int(featuresCount),
 		cParams,
@@ -871,12 +2422,145 @@ func boosterPredictForMatSingleRowFast(handle unsafe.Pointer, data []float64, re
 	return nil
 }
 
+func boosterCalcNumPredict(handle unsafe.Pointer, rowsCount int, predictType int, startIteration int, numIteration int) (int, error) {
+	var outLen int64
+
+	if handle == nil {
+		return 0, errInvalidHandle
+	}
+	if !isValidPredictType(predictType) {
+		return 0, errInvalidPredictType
+	}
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Calculate the number of output values
+	ret := C.call_LGBM_BoosterCalcNumPredict(
+		C.BoosterHandle(handle),
+		C.int(rowsCount),
+		C.int(predictType),
+		C.int(startIteration),
+		C.int(numIteration),
+		(*C.int64_t)(&outLen),
+	)
+	if ret != 0 {
+		return 0, getLastError()
+	}
+
+	// Done
+	return int(outLen), nil
+}
+
+func boosterPredictForMat(handle unsafe.Pointer, data []float64, rowsCount int, predictType int, startIteration int, numIteration int, parameters string, results []float64) error {
+	var outLen int64
+
+	if handle == nil {
+		return errInvalidHandle
+	}
+	if !isValidPredictType(predictType) {
+		return errInvalidPredictType
+	}
+	if len(data) == 0 || rowsCount <= 0 {
+		return errors.New("no data provided or number of rows is not positive")
+	}
+
+	featuresCount := len(data) / rowsCount
+
+	if len(data) != featuresCount*rowsCount {
+		return errors.New("data is not a matrix")
+	}
+
+	// Convert parameters
+	cParams := C.CString(parameters)
+	defer C.free(unsafe.Pointer(cParams))
+
+	// Lock thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Do prediction
+	ret := C.call_LGBM_BoosterPredictForMat(
+		C.BoosterHandle(handle),
+		unsafe.Pointer(&data[0]),
+		C.int(C.C_API_DTYPE_FLOAT64),
+		C.int32_t(rowsCount),
+		C.int32_t(featuresCount),
+		C.int(1),
+		C.int(predictType),
+		C.int(startIteration),
+		C.int(numIteration),
+		cParams,
+		(*C.int64_t)(&outLen),
+		(*C.double)(unsafe.Pointer(&results[0])),
+	)
+	runtime.KeepAlive(data) // Yes, keep-alive should be placed after the position where is used
+	runtime.KeepAlive(results)
+	if ret != 0 {
+		return getLastError()
+	}
+
+	// Done
+	return nil
+}
+
 func predictFastConfigFree(handle unsafe.Pointer) {
 	if handle != nil {
 		C.call_LGBM_FastConfigFree(C.FastConfigHandle(handle))
 	}
 }
 
+// getStringArray calls a library function that fills an array of strings, retrying with larger buffers
+// if the strings do not fit.
+func getStringArray(count int, fn func(cArray []*C.char, bufferLen int, outLen *int32, outBufferLen *C.size_t) C.int) ([]string, error) {
+	bufferLen := 256
+
+	for {
+		var outLen int32
+		var outBufferLen C.size_t
+
+		// Make room for the strings
+		cArray := make([]*C.char, count)
+		for idx := range cArray {
+			cArray[idx] = (*C.char)(C.malloc(C.size_t(bufferLen)))
+		}
+
+		ret := fn(cArray, bufferLen, &outLen, &outBufferLen)
+
+		// Copy the strings
+		var results []string
+		if ret == 0 && int(outBufferLen) <= bufferLen {
+			results = make([]string, int(outLen))
+			for idx := range results {
+				results[idx] = C.GoString(cArray[idx])
+			}
+		}
+
+		for idx := range cArray {
+			C.free(unsafe.Pointer(cArray[idx]))
+		}
+
+		if ret != 0 {
+			return nil, getLastError()
+		}
+		if results != nil {
+			return results, nil
+		}
+
+		// Not enough space, retry with the size required by the library
+		bufferLen = int(outBufferLen)
+	}
+}
+
+func isValidPredictType(predictType int) bool {
+	switch predictType {
+	case C.C_API_PREDICT_NORMAL, C.C_API_PREDICT_RAW_SCORE, C.C_API_PREDICT_LEAF_INDEX, C.C_API_PREDICT_CONTRIB:
+		return true
+	}
+	return false
+}
+
 func getLastError() error {
 	msg := C.call_LGBM_GetLastError()
 	if msg == nil {
@@ -906,6 +2590,35 @@ func savePointers(
 	ptr_LGBM_BoosterPredictForMatSingleRowFastInit unsafe.Pointer,
 	ptr_LGBM_BoosterPredictForMatSingleRowFast unsafe.Pointer,
 	ptr_LGBM_FastConfigFree unsafe.Pointer,
+	ptr_LGBM_BoosterCalcNumPredict unsafe.Pointer,
+	ptr_LGBM_BoosterPredictForMat unsafe.Pointer,
+	ptr_LGBM_DatasetCreateFromCSR unsafe.Pointer,
+	ptr_LGBM_DatasetCreateFromCSC unsafe.Pointer,
+	ptr_LGBM_DatasetCreateFromFile unsafe.Pointer,
+	ptr_LGBM_DatasetSaveBinary unsafe.Pointer,
+	ptr_LGBM_BoosterUpdateOneIterCustom unsafe.Pointer,
+	ptr_LGBM_BoosterGetPredict unsafe.Pointer,
+	ptr_LGBM_DatasetGetField unsafe.Pointer,
+	ptr_LGBM_BoosterGetEvalNames unsafe.Pointer,
+	ptr_LGBM_BoosterGetCurrentIteration unsafe.Pointer,
+	ptr_LGBM_BoosterRollbackOneIter unsafe.Pointer,
+	ptr_LGBM_BoosterNumberOfTotalModel unsafe.Pointer,
+	ptr_LGBM_BoosterNumModelPerIteration unsafe.Pointer,
+	ptr_LGBM_BoosterCreateFromModelfile unsafe.Pointer,
+	ptr_LGBM_BoosterSaveModel unsafe.Pointer,
+	ptr_LGBM_BoosterDumpModel unsafe.Pointer,
+	ptr_LGBM_BoosterFeatureImportance unsafe.Pointer,
+	ptr_LGBM_BoosterGetFeatureNames unsafe.Pointer,
+	ptr_LGBM_DatasetCreateByReference unsafe.Pointer,
+	ptr_LGBM_DatasetInitStreaming unsafe.Pointer,
+	ptr_LGBM_DatasetPushRowsWithMetadata unsafe.Pointer,
+	ptr_LGBM_DatasetMarkFinished unsafe.Pointer,
+	ptr_LGBM_DatasetSetWaitForManualFinish unsafe.Pointer,
+	ptr_LGBM_DatasetGetNumData unsafe.Pointer,
+	ptr_LGBM_DatasetGetNumFeature unsafe.Pointer,
+	ptr_LGBM_DatasetGetFeatureNumBin unsafe.Pointer,
+	ptr_LGBM_DatasetGetFeatureNames unsafe.Pointer,
+	ptr_LGBM_DatasetGetSubset unsafe.Pointer,
 ) {
 	C.savePointers(
 		ptr_LGBM_GetLastError,
@@ -928,5 +2641,34 @@ func savePointers(
 		ptr_LGBM_BoosterPredictForMatSingleRowFastInit,
 		ptr_LGBM_BoosterPredictForMatSingleRowFast,
 		ptr_LGBM_FastConfigFree,
+		ptr_LGBM_BoosterCalcNumPredict,
+		ptr_LGBM_BoosterPredictForMat,
+		ptr_LGBM_DatasetCreateFromCSR,
+		ptr_LGBM_DatasetCreateFromCSC,
+		ptr_LGBM_DatasetCreateFromFile,
+		ptr_LGBM_DatasetSaveBinary,
+		ptr_LGBM_BoosterUpdateOneIterCustom,
+		ptr_LGBM_BoosterGetPredict,
+		ptr_LGBM_DatasetGetField,
+		ptr_LGBM_BoosterGetEvalNames,
+		ptr_LGBM_BoosterGetCurrentIteration,
+		ptr_LGBM_BoosterRollbackOneIter,
+		ptr_LGBM_BoosterNumberOfTotalModel,
+		ptr_LGBM_BoosterNumModelPerIteration,
+		ptr_LGBM_BoosterCreateFromModelfile,
+		ptr_LGBM_BoosterSaveModel,
+		ptr_LGBM_BoosterDumpModel,
+		ptr_LGBM_BoosterFeatureImportance,
+		ptr_LGBM_BoosterGetFeatureNames,
+		ptr_LGBM_DatasetCreateByReference,
+		ptr_LGBM_DatasetInitStreaming,
+		ptr_LGBM_DatasetPushRowsWithMetadata,
+		ptr_LGBM_DatasetMarkFinished,
+		ptr_LGBM_DatasetSetWaitForManualFinish,
+		ptr_LGBM_DatasetGetNumData,
+		ptr_LGBM_DatasetGetNumFeature,
+		ptr_LGBM_DatasetGetFeatureNumBin,
+		ptr_LGBM_DatasetGetFeatureNames,
+		ptr_LGBM_DatasetGetSubset,
 	)
 }